@@ -2,10 +2,13 @@ package api
 
 import (
 	"database/sql"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,11 +16,14 @@ import (
 	janus_admin "github.com/edoshor/janus-go/admin"
 	janus_plugins "github.com/edoshor/janus-go/plugins"
 	"github.com/gorilla/mux"
+	"github.com/lib/pq"
 	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/hlog"
 	"github.com/rs/zerolog/log"
 	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 	"github.com/volatiletech/sqlboiler/queries/qm"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
 	"github.com/Bnei-Baruch/gxydb-api/domain"
@@ -82,477 +88,603 @@ func (a *App) AdminListGateways(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func (a *App) AdminGatewaysHandleInfo(w http.ResponseWriter, r *http.Request) {
-	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+func (a *App) AdminGetGateway(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	vars := mux.Vars(r)
-	gatewayID := vars["gateway_id"]
-	gateway, ok := a.cache.gateways.ByName(gatewayID)
-	if !ok {
-		httputil.NewNotFoundError().Abort(w, r)
+	gateway, hErr := a.findGateway(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
 		return
 	}
 
-	sessionIDStr := vars["session_id"]
-	sessionID, err := strconv.ParseUint(sessionIDStr, 10, 64)
-	if err != nil {
-		httputil.NewNotFoundError().Abort(w, r)
+	httputil.RespondWithJSON(w, http.StatusOK, NewGatewayDTO(gateway))
+}
+
+func (a *App) AdminCreateGateway(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	handleIDStr := vars["handle_id"]
-	handleID, err := strconv.ParseUint(handleIDStr, 10, 64)
-	if err != nil {
-		httputil.NewNotFoundError().Abort(w, r)
+	var data GatewayRequest
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
 		return
 	}
+	a.requestContext(r).Params = data.redacted()
 
-	api, err := domain.GatewayAdminAPIRegistry.For(gateway)
-	if err != nil {
-		httputil.NewInternalError(pkgerr.WithMessage(err, "init admin api")).Abort(w, r)
+	if err := data.Validate(true); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
 		return
 	}
 
-	info, err := api.HandleInfo(sessionID, handleID)
-	if err != nil {
-		var tErr *janus_admin.ErrorAMResponse
-		if errors.As(err, &tErr) {
-			if tErr.Err.Code == 458 || tErr.Err.Code == 459 { // no such session or no such handle
-				httputil.NewNotFoundError().Abort(w, r)
-				return
-			}
-		}
-		httputil.NewInternalError(pkgerr.Wrap(err, "api.HandleInfo")).Abort(w, r)
+	if _, ok := a.cache.gateways.ByName(data.Name); ok {
+		httputil.NewBadRequestError(nil, "gateway already exists [name]").Abort(w, r)
 		return
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, info)
+	if err := domain.CheckAdminAPI(data.AdminURL, data.AdminPassword); err != nil {
+		httputil.NewBadRequestError(err, "gateway admin API is unreachable").Abort(w, r)
+		return
+	}
+
+	gateway := &models.Gateway{
+		Name:        data.Name,
+		Description: data.Description,
+		URL:         data.URL,
+		AdminURL:    data.AdminURL,
+		Type:        data.Type,
+		Disabled:    data.Disabled,
+		Region:      data.Region,
+		MaxSessions: data.MaxSessions,
+	}
+	if hErr := setGatewayPasswords(gateway, &data); hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	if err := gateway.Insert(a.DB, boil.Infer()); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	a.cache.gateways.Set(gateway)
+
+	httputil.RespondWithJSON(w, http.StatusCreated, NewGatewayDTO(gateway))
 }
 
-func (a *App) AdminListRooms(w http.ResponseWriter, r *http.Request) {
+func (a *App) AdminUpdateGateway(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	query := r.URL.Query()
-	listParams, err := ParseListParams(query)
-	if err != nil {
-		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+	gateway, hErr := a.findGateway(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
 		return
 	}
 
-	roomsRequest, err := ParseRoomsRequest(query)
-	if err != nil {
-		httputil.NewBadRequestError(err, "malformed rooms request parameters").Abort(w, r)
+	var data GatewayRequest
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
 		return
 	}
+	a.requestContext(r).Params = data.redacted()
 
-	mods := make([]qm.QueryMod, 0)
-
-	// filters
-	if roomsRequest.Disabled.Valid {
-		mods = append(mods, models.RoomWhere.Disabled.EQ(roomsRequest.Disabled.Bool))
+	// name is how gateways identify themselves, it can't be changed
+	data.Name = gateway.Name
+	if err := data.Validate(false); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
 	}
-	if roomsRequest.Removed.Valid {
-		if roomsRequest.Removed.Bool {
-			mods = append(mods, models.RoomWhere.RemovedAt.IsNotNull())
-		} else {
-			mods = append(mods, models.RoomWhere.RemovedAt.IsNull())
+
+	// check connectivity of enabled gateways when the admin API changes or the gateway is enabled
+	if !data.Disabled && (gateway.Disabled || data.AdminURL != gateway.AdminURL || data.AdminPassword != "") {
+		adminPwd := data.AdminPassword
+		if adminPwd == "" {
+			var err error
+			if adminPwd, err = domain.AdminPassword(gateway); err != nil {
+				httputil.NewInternalError(err).Abort(w, r)
+				return
+			}
+		}
+		if err := domain.CheckAdminAPI(data.AdminURL, adminPwd); err != nil {
+			httputil.NewBadRequestError(err, "gateway admin API is unreachable").Abort(w, r)
+			return
 		}
 	}
-	if len(roomsRequest.Gateways) > 0 {
-		mods = append(mods, models.RoomWhere.DefaultGatewayID.IN(roomsRequest.Gateways))
+
+	gateway.Description = data.Description
+	gateway.URL = data.URL
+	gateway.AdminURL = data.AdminURL
+	gateway.Type = data.Type
+	gateway.Disabled = data.Disabled
+	gateway.Region = data.Region
+	gateway.MaxSessions = data.MaxSessions
+	gateway.UpdatedAt = null.TimeFrom(time.Now().UTC())
+	if hErr := setGatewayPasswords(gateway, &data); hErr != nil {
+		hErr.Abort(w, r)
+		return
 	}
-	if len(roomsRequest.Term) > 0 {
-		var clauses []string
-		var args []interface{}
 
-		// numeric value ?
-		if numVal, err := strconv.ParseUint(roomsRequest.Term, 10, 64); err == nil {
-			clauses = append(clauses,
-				fmt.Sprintf("%s = ?", models.RoomColumns.ID),
-				fmt.Sprintf("%s = ?", models.RoomColumns.GatewayUID))
-			args = append(args, numVal, numVal)
-		}
+	if _, err := gateway.Update(a.DB, boil.Whitelist(
+		models.GatewayColumns.Description,
+		models.GatewayColumns.URL,
+		models.GatewayColumns.AdminURL,
+		models.GatewayColumns.AdminPassword,
+		models.GatewayColumns.EventsPassword,
+		models.GatewayColumns.Type,
+		models.GatewayColumns.Disabled,
+		models.GatewayColumns.Region,
+		models.GatewayColumns.MaxSessions,
+		models.GatewayColumns.UpdatedAt)); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
 
-		clauses = append(clauses, "name ~* ?")
-		args = append(args, roomsRequest.Term)
+	a.cache.gateways.Set(gateway)
+	domain.GatewayAdminAPIRegistry.Remove(gateway)
+	domain.GatewayHealthRegistry.Remove(gateway)
 
-		mods = append(mods, qm.Where(strings.Join(clauses, " OR "), args...))
+	httputil.RespondWithJSON(w, http.StatusOK, NewGatewayDTO(gateway))
+}
+
+func (a *App) AdminDeleteGateway(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
 	}
 
-	// count query
-	var total int64
-	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
-	err = models.Rooms(countMods...).QueryRow(a.DB).Scan(&total)
+	gateway, hErr := a.findGateway(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	count, err := models.Rooms(
+		models.RoomWhere.DefaultGatewayID.EQ(gateway.ID),
+		models.RoomWhere.RemovedAt.IsNull(),
+	).Count(a.DB)
 	if err != nil {
-		httputil.NewInternalError(err).Abort(w, r)
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
 		return
-	} else if total == 0 {
-		httputil.RespondWithJSON(w, http.StatusOK, RoomsResponse{Rooms: make([]*models.Room, 0)})
+	} else if count > 0 {
+		httputil.NewBadRequestError(nil, fmt.Sprintf("gateway is the default gateway of %d rooms", count)).Abort(w, r)
 		return
 	}
 
-	// order, limit, offset
-	_, offset := listParams.appendListMods(&mods)
-	if int64(offset) >= total {
-		httputil.RespondWithJSON(w, http.StatusOK, RoomsResponse{Rooms: make([]*models.Room, 0)})
+	// drain the gateway first
+	count, err = models.Sessions(
+		models.SessionWhere.GatewayID.EQ(null.Int64From(gateway.ID)),
+		models.SessionWhere.RemovedAt.IsNull(),
+	).Count(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	} else if count > 0 {
+		httputil.NewBadRequestError(nil, fmt.Sprintf("gateway has %d active sessions", count)).Abort(w, r)
 		return
 	}
 
-	// data query
-	rooms, err := models.Rooms(mods...).All(a.DB)
-	if err != nil {
-		httputil.NewInternalError(err).Abort(w, r)
+	gateway.RemovedAt = null.TimeFrom(time.Now().UTC())
+	if _, err := gateway.Update(a.DB, boil.Whitelist(models.GatewayColumns.RemovedAt)); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
 		return
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, RoomsResponse{
-		ListResponse: ListResponse{
-			Total: total,
-		},
-		Rooms: rooms,
-	})
+	a.cache.gateways.Set(gateway)
+	domain.GatewayAdminAPIRegistry.Remove(gateway)
+	domain.GatewayHealthRegistry.Remove(gateway)
+
+	httputil.RespondSuccess(w)
 }
 
-func (a *App) AdminCreateRoom(w http.ResponseWriter, r *http.Request) {
+// AdminDrainGateway stops new users from landing on the gateway while current users finish.
+// Users on the gateway are hinted to migrate. Draining an already draining gateway hints them again.
+func (a *App) AdminDrainGateway(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	var data models.Room
-	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
-		err.Abort(w, r)
+	gateway, hErr := a.findGateway(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
 		return
 	}
-	a.requestContext(r).Params = data
 
-	if _, ok := a.cache.gateways.ByID(data.DefaultGatewayID); !ok {
-		httputil.NewBadRequestError(nil, "gateway doesn't exists").Abort(w, r)
+	if gateway.Type != common.GatewayTypeRooms {
+		httputil.NewBadRequestError(nil, "only rooms gateways could be drained").Abort(w, r)
+		return
+	}
+	if gateway.Disabled {
+		httputil.NewBadRequestError(nil, "gateway is disabled").Abort(w, r)
 		return
 	}
 
-	if exists, _ := models.Rooms(models.RoomWhere.Name.EQ(data.Name)).Exists(a.DB); exists {
-		httputil.NewBadRequestError(nil, "room already exists [name]").Abort(w, r)
+	if !gateway.Draining {
+		gateway.Draining = true
+		gateway.UpdatedAt = null.TimeFrom(time.Now().UTC())
+		if _, err := gateway.Update(a.DB, boil.Whitelist(
+			models.GatewayColumns.Draining,
+			models.GatewayColumns.UpdatedAt)); err != nil {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+			return
+		}
+		a.cache.gateways.Set(gateway)
+	}
+
+	hints, err := a.migrateHints(gateway)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
+	a.roomsStream.Publish(hints)
+	if a.mqttListener != nil {
+		a.mqttListener.PublishUserEvents(hints)
+	}
 
-	if len(data.Name) == 0 || len(data.Name) > 64 {
-		httputil.NewBadRequestError(nil, "name is missing or longer than 64 characters").Abort(w, r)
+	status, err := a.drainStatus([]*models.Gateway{gateway})
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
+	status[0].Hints = len(hints)
 
-	if data.GatewayUID == "0" {
-		var maxUID int
-		if err := models.NewQuery(qm.Select("coalesce(max(gateway_uid), 0) + 1"), qm.From(models.TableNames.Rooms)).
-			QueryRow(a.DB).Scan(&maxUID); err != nil {
-			httputil.NewInternalError(pkgerr.Wrap(err, "fetch max gateway_uid")).Abort(w, r)
-			return
-		}
-		data.GatewayUID = strconv.Itoa(maxUID)
-	} else if (gatewayUID, _ := strconv.Atoi(data.GatewayUID)) < 0 {
-		httputil.NewBadRequestError(nil, "gateway_uid must be a positive integer").Abort(w, r)
+	httputil.RespondWithJSON(w, http.StatusOK, status[0])
+}
+
+func (a *App) AdminUndrainGateway(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
 		return
-	} else if _, ok := a.cache.rooms.ByGatewayUID(data.GatewayUID); ok {
-		httputil.NewBadRequestError(nil, "room already exists [gateway_uid]").Abort(w, r)
+	}
+
+	gateway, hErr := a.findGateway(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
 		return
 	}
 
-	err := sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		// create room in DB
-		if err := data.Insert(tx, boil.Whitelist("name", "default_gateway_id", "gateway_uid", "disabled", "region")); err != nil {
-			return pkgerr.WithStack(err)
+	if gateway.Draining {
+		gateway.Draining = false
+		gateway.UpdatedAt = null.TimeFrom(time.Now().UTC())
+		if _, err := gateway.Update(a.DB, boil.Whitelist(
+			models.GatewayColumns.Draining,
+			models.GatewayColumns.UpdatedAt)); err != nil {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+			return
 		}
+		a.cache.gateways.Set(gateway)
+	}
 
-		// create room in gateways
-		room := &janus_plugins.VideoroomRoom{
-			Room:               data.GatewayUID,
-			Description:        data.Name,
-			Secret:             common.Config.GatewayRoomsSecret,
-			Publishers:         100,
-			Bitrate:            64000,
-			FirFreq:            10,
-			AudioCodec:         "opus",
-			VideoCodec:         "h264",
-			H264Profile:        "42e01f",
-			AudioLevelExt:      true,
-			AudioLevelEvent:    true,
-			AudioActivePackets: 25,
-			AudioLevelAverage:  100,
-			VideoOrientExt:     true,
-			PlayoutDelayExt:    true,
-			TransportWideCCExt: true,
-		}
-		request := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey).
-			CreateRequest(room, true, nil)
-
-		for _, gateway := range a.cache.gateways.Values() {
-			if gateway.Disabled || gateway.RemovedAt.Valid || gateway.Type != common.GatewayTypeRooms {
-				continue
-			}
+	httputil.RespondWithJSON(w, http.StatusOK, NewGatewayDTO(gateway))
+}
 
-			api, err := domain.GatewayAdminAPIRegistry.For(gateway)
-			if err != nil {
-				return pkgerr.WithMessage(err, "Admin API for gateway")
-			}
+// AdminListDrainingGateways reports the sessions remaining on draining gateways.
+// A draining gateway with no sessions is safe to restart.
+func (a *App) AdminListDrainingGateways(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
 
-			if _, err = api.MessagePlugin(request); err != nil {
-				return pkgerr.Wrap(err, "api.MessagePlugin [videoroom]")
-			}
+	gateways := make([]*models.Gateway, 0)
+	for _, gateway := range a.cache.gateways.Values() {
+		if gateway.Draining && !gateway.RemovedAt.Valid {
+			gateways = append(gateways, gateway)
 		}
-
-		return nil
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		return gateways[i].Name < gateways[j].Name
 	})
 
+	status, err := a.drainStatus(gateways)
 	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
-		}
+		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
 
-	if err := a.cache.rooms.Reload(a.DB); err != nil {
-		log.Error().Err(err).Msg("Reload cache")
-	}
-
-	httputil.RespondWithJSON(w, http.StatusCreated, data)
+	httputil.RespondWithJSON(w, http.StatusOK, status)
 }
 
-func (a *App) AdminGetRoom(w http.ResponseWriter, r *http.Request) {
-	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
-		httputil.NewForbiddenError().Abort(w, r)
-		return
+// drainStatus counts the active sessions remaining on each of the given gateways
+func (a *App) drainStatus(gateways []*models.Gateway) ([]*GatewayDrainStatus, error) {
+	status := make([]*GatewayDrainStatus, len(gateways))
+	ids := make([]int64, len(gateways))
+	idx := make(map[int64]*GatewayDrainStatus, len(gateways))
+	for i, gateway := range gateways {
+		status[i] = &GatewayDrainStatus{
+			ID:       gateway.ID,
+			Name:     gateway.Name,
+			Draining: gateway.Draining,
+		}
+		ids[i] = gateway.ID
+		idx[gateway.ID] = status[i]
+	}
+	if len(gateways) == 0 {
+		return status, nil
 	}
 
-	vars := mux.Vars(r)
-	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	rows, err := models.Sessions(
+		qm.Select("gateway_id", "count(*)"),
+		qm.Where("gateway_id = ANY(?)", pq.Array(ids)),
+		models.SessionWhere.RemovedAt.IsNull(),
+		qm.GroupBy("gateway_id"),
+	).Query.Query(a.DB)
 	if err != nil {
-		httputil.NewNotFoundError().Abort(w, r)
-		return
+		return nil, pkgerr.WithStack(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var gatewayID int64
+		var count int
+		if err := rows.Scan(&gatewayID, &count); err != nil {
+			return nil, pkgerr.WithStack(err)
+		}
+		idx[gatewayID].Sessions = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pkgerr.WithStack(err)
 	}
 
-	room, err := models.FindRoom(a.DB, id)
+	return status, nil
+}
+
+// findGateway fetches the gateway of the {id} path parameter
+func (a *App) findGateway(r *http.Request) (*models.Gateway, *httputil.HttpError) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, httputil.NewNotFoundError()
+	}
+
+	gateway, err := models.Gateways(
+		models.GatewayWhere.ID.EQ(id),
+		models.GatewayWhere.RemovedAt.IsNull(),
+	).One(a.DB)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			httputil.NewNotFoundError().Abort(w, r)
-		} else {
-			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+			return nil, httputil.NewNotFoundError()
 		}
-		return
+		return nil, httputil.NewInternalError(pkgerr.WithStack(err))
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, room)
+	return gateway, nil
 }
 
-func (a *App) AdminUpdateRoom(w http.ResponseWriter, r *http.Request) {
-	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+// setGatewayPasswords encrypts the admin password and hashes the events password given in plain text (if any)
+func setGatewayPasswords(gateway *models.Gateway, data *GatewayRequest) *httputil.HttpError {
+	if data.AdminPassword != "" {
+		encPwd, err := domain.EncryptAdminPassword(data.AdminPassword)
+		if err != nil {
+			return httputil.NewInternalError(err)
+		}
+		gateway.AdminPassword = encPwd
+	}
+
+	if data.EventsPassword != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(data.EventsPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return httputil.NewInternalError(pkgerr.Wrap(err, "bcrypt.GenerateFromPassword"))
+		}
+		gateway.EventsPassword = string(hash)
+	}
+
+	return nil
+}
+
+func (a *App) AdminGatewaySessions(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	vars := mux.Vars(r)
-	id, err := strconv.ParseInt(vars["id"], 10, 64)
-	if err != nil {
-		httputil.NewNotFoundError().Abort(w, r)
+	gateway, api, hErr := a.gatewayAdminAPI(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
 		return
 	}
 
-	room, err := models.FindRoom(a.DB, id)
+	apiRes, err := api.ListSessions()
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			httputil.NewNotFoundError().Abort(w, r)
-		} else {
-			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
-		}
+		httputil.NewInternalError(pkgerr.Wrap(err, "api.ListSessions")).Abort(w, r)
 		return
 	}
-
-	var data models.Room
-	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
-		err.Abort(w, r)
+	tApiRes, ok := apiRes.(*janus_admin.ListSessionsResponse)
+	if !ok {
+		httputil.NewInternalError(pkgerr.Errorf("unexpected api.ListSessions response: %+v", apiRes)).Abort(w, r)
 		return
 	}
-	a.requestContext(r).Params = data
 
-	if data.GatewayUID <= 0 {
-		httputil.NewBadRequestError(nil, "gateway_uid must be a positive integer").Abort(w, r)
+	dbSessions, err := a.gatewayDBSessions(gateway)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
 
-	if len(data.Name) == 0 || len(data.Name) > 64 {
-		httputil.NewBadRequestError(nil, "name is missing or longer than 64 characters").Abort(w, r)
-		return
+	data := make([]*GatewaySessionDTO, len(tApiRes.Sessions))
+	for i, id := range tApiRes.Sessions {
+		data[i] = NewGatewaySessionDTO(id, dbSessions[id])
 	}
 
-	if _, ok := a.cache.gateways.ByID(data.DefaultGatewayID); !ok {
-		httputil.NewBadRequestError(nil, "gateway doesn't exists").Abort(w, r)
+	httputil.RespondWithJSON(w, http.StatusOK, data)
+}
+
+func (a *App) AdminGatewaySessionHandles(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	if exists, _ := models.Rooms(models.RoomWhere.GatewayUID.EQ(data.GatewayUID), models.RoomWhere.ID.NEQ(room.ID)).Exists(a.DB); exists {
-		httputil.NewBadRequestError(nil, "room already exists [gateway_uid]").Abort(w, r)
+	gateway, api, hErr := a.gatewayAdminAPI(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
 		return
 	}
 
-	if exists, _ := models.Rooms(models.RoomWhere.Name.EQ(data.Name), models.RoomWhere.ID.NEQ(room.ID)).Exists(a.DB); exists {
-		httputil.NewBadRequestError(nil, "room already exists [name]").Abort(w, r)
+	sessionID, err := strconv.ParseUint(mux.Vars(r)["session_id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
 		return
 	}
 
-	err = sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		shouldUpdateGateways := room.Name != data.Name &&
-			!room.RemovedAt.Valid
-
-		// update room in DB
-		room.Name = data.Name
-		room.DefaultGatewayID = data.DefaultGatewayID
-		room.Disabled = data.Disabled
-		room.Region = data.Region
-		room.UpdatedAt = null.TimeFrom(time.Now().UTC())
-		if _, err := room.Update(tx, boil.Whitelist("name", "default_gateway_id", "disabled", "region", "updated_at")); err != nil {
-			return pkgerr.WithStack(err)
-		}
-
-		if !shouldUpdateGateways {
-			return nil
-		}
-
-		// update room in gateways
-		room := &janus_plugins.VideoroomRoomForEdit{
-			Room:        data.GatewayUID,
-			Description: data.Name,
-			Publishers:  100,   // same as create
-			Bitrate:     64000, // same as create
-			FirFreq:     10,    // same as create
-		}
-		request := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey).
-			EditRequest(room, true, common.Config.GatewayRoomsSecret)
-
-		for _, gateway := range a.cache.gateways.Values() {
-			if gateway.Disabled || gateway.RemovedAt.Valid || gateway.Type != common.GatewayTypeRooms {
-				continue
-			}
-
-			api, err := domain.GatewayAdminAPIRegistry.For(gateway)
-			if err != nil {
-				return pkgerr.WithMessage(err, "Admin API for gateway")
-			}
-
-			if _, err = api.MessagePlugin(request); err != nil {
-				return pkgerr.Wrap(err, "api.MessagePlugin [videoroom]")
+	apiRes, err := api.ListHandles(sessionID)
+	if err != nil {
+		var tErr *janus_admin.ErrorAMResponse
+		if errors.As(err, &tErr) {
+			if tErr.Err.Code == 458 { // no such session
+				httputil.NewNotFoundError().Abort(w, r)
+				return
 			}
 		}
+		httputil.NewInternalError(pkgerr.Wrap(err, "api.ListHandles")).Abort(w, r)
+		return
+	}
+	tApiRes, ok := apiRes.(*janus_admin.ListHandlesResponse)
+	if !ok {
+		httputil.NewInternalError(pkgerr.Errorf("unexpected api.ListHandles response: %+v", apiRes)).Abort(w, r)
+		return
+	}
 
-		return nil
-	})
-
+	dbSessions, err := a.gatewayDBSessions(gateway)
 	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
-		}
+		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
+	dbSession := dbSessions[sessionID]
 
-	if err := a.cache.rooms.Reload(a.DB); err != nil {
-		log.Error().Err(err).Msg("Reload cache")
+	handles := make([]*GatewayHandleDTO, len(tApiRes.Handles))
+	for i, id := range tApiRes.Handles {
+		handles[i] = &GatewayHandleDTO{ID: id}
+		if dbSession == nil {
+			continue
+		}
+		if dbSession.GatewayHandle.Valid && uint64(dbSession.GatewayHandle.Int64) == id {
+			handles[i].Plugin = "janus.plugin.videoroom"
+		} else if dbSession.GatewayHandleTextroom.Valid && uint64(dbSession.GatewayHandleTextroom.Int64) == id {
+			handles[i].Plugin = "janus.plugin.textroom"
+		}
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, room)
+	httputil.RespondWithJSON(w, http.StatusOK, GatewaySessionHandlesResponse{
+		GatewaySessionDTO: NewGatewaySessionDTO(sessionID, dbSession),
+		Handles:           handles,
+	})
 }
 
-func (a *App) AdminDeleteRoom(w http.ResponseWriter, r *http.Request) {
-	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+func (a *App) AdminGatewaysHandleInfo(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
+	_, api, hErr := a.gatewayAdminAPI(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
 	vars := mux.Vars(r)
-	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	sessionIDStr := vars["session_id"]
+	sessionID, err := strconv.ParseUint(sessionIDStr, 10, 64)
 	if err != nil {
 		httputil.NewNotFoundError().Abort(w, r)
 		return
 	}
 
-	room, err := models.FindRoom(a.DB, id)
+	handleIDStr := vars["handle_id"]
+	handleID, err := strconv.ParseUint(handleIDStr, 10, 64)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			httputil.NewNotFoundError().Abort(w, r)
-		} else {
-			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
-		}
+		httputil.NewNotFoundError().Abort(w, r)
 		return
 	}
 
-	err = sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		room.RemovedAt = null.TimeFrom(time.Now().UTC())
-		if _, err := room.Update(tx, boil.Whitelist(models.RoomColumns.RemovedAt)); err != nil {
-			return httputil.NewInternalError(pkgerr.WithStack(err))
-		}
-
-		request := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey).
-			DestroyRequest(room.GatewayUID, true, common.Config.GatewayRoomsSecret)
-
-		for _, gateway := range a.cache.gateways.Values() {
-			if gateway.Disabled || gateway.RemovedAt.Valid || gateway.Type != common.GatewayTypeRooms {
-				continue
+	info, err := api.HandleInfo(sessionID, handleID)
+	if err != nil {
+		var tErr *janus_admin.ErrorAMResponse
+		if errors.As(err, &tErr) {
+			if tErr.Err.Code == 458 || tErr.Err.Code == 459 { // no such session or no such handle
+				httputil.NewNotFoundError().Abort(w, r)
+				return
 			}
+		}
+		httputil.NewInternalError(pkgerr.Wrap(err, "api.HandleInfo")).Abort(w, r)
+		return
+	}
 
-			api, err := domain.GatewayAdminAPIRegistry.For(gateway)
-			if err != nil {
-				return pkgerr.WithMessage(err, "Admin API for gateway")
-			}
+	httputil.RespondWithJSON(w, http.StatusOK, info)
+}
 
-			if _, err = api.MessagePlugin(request); err != nil {
-				return pkgerr.Wrap(err, "api.MessagePlugin [videoroom]")
-			}
-		}
+func (a *App) AdminGatewayInfo(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
 
-		return nil
-	})
+	gateway, ok := a.browsedGateway(r)
+	if !ok {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
 
+	info, err := domain.GatewayInfo(gateway)
 	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
-		}
+		httputil.NewInternalError(pkgerr.WithMessage(err, "gateway info")).Abort(w, r)
 		return
 	}
 
-	if err := a.cache.rooms.Reload(a.DB); err != nil {
-		log.Error().Err(err).Msg("Reload cache")
-	}
+	httputil.RespondWithJSON(w, http.StatusOK, info)
+}
 
-	httputil.RespondSuccess(w)
+// browsedGateway returns the gateway named by the gateway_id route var, unless it was removed
+func (a *App) browsedGateway(r *http.Request) (*models.Gateway, bool) {
+	gateway, ok := a.cache.gateways.ByName(mux.Vars(r)["gateway_id"])
+	if !ok || gateway.RemovedAt.Valid {
+		return nil, false
+	}
+	return gateway, true
 }
 
-func (a *App) AdminDeleteRoomsStatistics(w http.ResponseWriter, r *http.Request) {
-	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleShidur, common.RoleRoot) {
-		httputil.NewForbiddenError().Abort(w, r)
-		return
+// gatewayAdminAPI returns the gateway named by the gateway_id route var and its admin API
+func (a *App) gatewayAdminAPI(r *http.Request) (*models.Gateway, janus_admin.AdminAPI, *httputil.HttpError) {
+	gateway, ok := a.browsedGateway(r)
+	if !ok {
+		return nil, nil, httputil.NewNotFoundError()
 	}
 
-	if err := a.roomsStatisticsManager.Reset(r.Context()); err != nil {
-		httputil.NewInternalError(err).Abort(w, r)
-		return
+	api, err := domain.GatewayAdminAPIRegistry.For(gateway)
+	if err != nil {
+		return nil, nil, httputil.NewInternalError(pkgerr.WithMessage(err, "init admin api"))
 	}
 
-	httputil.RespondSuccess(w)
+	return gateway, api, nil
 }
 
-func (a *App) AdminListDynamicConfigs(w http.ResponseWriter, r *http.Request) {
+// gatewayDBSessions returns the active sessions on the gateway by their janus session id
+func (a *App) gatewayDBSessions(gateway *models.Gateway) (map[uint64]*models.Session, error) {
+	sessions, err := models.Sessions(
+		models.SessionWhere.GatewayID.EQ(null.Int64From(gateway.ID)),
+		models.SessionWhere.GatewaySession.IsNotNull(),
+		models.SessionWhere.RemovedAt.IsNull(),
+		qm.Load(models.SessionRels.User),
+		qm.Load(models.SessionRels.Room),
+	).All(a.DB)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch sessions")
+	}
+
+	byID := make(map[uint64]*models.Session, len(sessions))
+	for _, session := range sessions {
+		byID[uint64(session.GatewaySession.Int64)] = session
+	}
+	return byID, nil
+}
+
+func (a *App) AdminListRooms(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
@@ -565,99 +697,152 @@ func (a *App) AdminListDynamicConfigs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	roomsRequest, err := ParseRoomsRequest(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed rooms request parameters").Abort(w, r)
+		return
+	}
+
 	mods := make([]qm.QueryMod, 0)
 
+	// filters
+	if roomsRequest.Disabled.Valid {
+		mods = append(mods, models.RoomWhere.Disabled.EQ(roomsRequest.Disabled.Bool))
+	}
+	if roomsRequest.Removed.Valid {
+		if roomsRequest.Removed.Bool {
+			mods = append(mods, models.RoomWhere.RemovedAt.IsNotNull())
+		} else {
+			mods = append(mods, models.RoomWhere.RemovedAt.IsNull())
+		}
+	}
+	if len(roomsRequest.Gateways) > 0 {
+		mods = append(mods, models.RoomWhere.DefaultGatewayID.IN(roomsRequest.Gateways))
+	}
+	if len(roomsRequest.Term) > 0 {
+		var clauses []string
+		var args []interface{}
+
+		// numeric value ?
+		if numVal, err := strconv.ParseUint(roomsRequest.Term, 10, 64); err == nil {
+			clauses = append(clauses,
+				fmt.Sprintf("%s = ?", models.RoomColumns.ID),
+				fmt.Sprintf("%s = ?", models.RoomColumns.GatewayUID))
+			args = append(args, numVal, numVal)
+		}
+
+		clauses = append(clauses, "name ~* ?")
+		args = append(args, roomsRequest.Term)
+
+		mods = append(mods, qm.Where(strings.Join(clauses, " OR "), args...))
+	}
+
 	// count query
 	var total int64
 	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
-	err = models.DynamicConfigs(countMods...).QueryRow(a.DB).Scan(&total)
+	err = models.Rooms(countMods...).QueryRow(a.DB).Scan(&total)
 	if err != nil {
 		httputil.NewInternalError(err).Abort(w, r)
 		return
 	} else if total == 0 {
-		httputil.RespondWithJSON(w, http.StatusOK, DynamicConfigsResponse{Items: make([]*models.DynamicConfig, 0)})
+		httputil.RespondWithJSON(w, http.StatusOK, RoomsResponse{Rooms: make([]*models.Room, 0)})
 		return
 	}
 
 	// order, limit, offset
-	if listParams.OrderBy == "" {
-		listParams.OrderBy = "key asc"
-	}
 	_, offset := listParams.appendListMods(&mods)
 	if int64(offset) >= total {
-		httputil.RespondWithJSON(w, http.StatusOK, DynamicConfigsResponse{Items: make([]*models.DynamicConfig, 0)})
+		httputil.RespondWithJSON(w, http.StatusOK, RoomsResponse{Rooms: make([]*models.Room, 0)})
 		return
 	}
 
 	// data query
-	kvs, err := models.DynamicConfigs(mods...).All(a.DB)
+	rooms, err := models.Rooms(mods...).All(a.DB)
 	if err != nil {
 		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, DynamicConfigsResponse{
+	httputil.RespondWithJSON(w, http.StatusOK, RoomsResponse{
 		ListResponse: ListResponse{
 			Total: total,
 		},
-		Items: kvs,
+		Rooms: rooms,
 	})
 }
 
-func (a *App) AdminCreateDynamicConfig(w http.ResponseWriter, r *http.Request) {
+func (a *App) AdminCreateRoom(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	var data models.DynamicConfig
+	var data models.Room
 	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
 		err.Abort(w, r)
 		return
 	}
 	a.requestContext(r).Params = data
 
-	if len(data.Key) == 0 || len(data.Key) > 255 {
-		httputil.NewBadRequestError(nil, "key is missing or longer than 255 characters").Abort(w, r)
+	if _, ok := a.cache.gateways.ByID(data.DefaultGatewayID); !ok {
+		httputil.NewBadRequestError(nil, "gateway doesn't exists").Abort(w, r)
 		return
 	}
 
-	if len(data.Value) == 0 {
-		httputil.NewBadRequestError(nil, "value is missing").Abort(w, r)
+	if exists, _ := models.Rooms(models.RoomWhere.Name.EQ(data.Name)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "room already exists [name]").Abort(w, r)
 		return
 	}
 
-	if exists, _ := models.DynamicConfigs(models.DynamicConfigWhere.Key.EQ(data.Key)).Exists(a.DB); exists {
-		httputil.NewBadRequestError(nil, "key already exists").Abort(w, r)
+	if len(data.Name) == 0 || len(data.Name) > 64 {
+		httputil.NewBadRequestError(nil, "name is missing or longer than 64 characters").Abort(w, r)
 		return
 	}
 
-	err := sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		data.UpdatedAt = time.Now().UTC()
-		if err := data.Insert(tx, boil.Whitelist("key", "value", "updated_at")); err != nil {
+	if data.GatewayUID == "0" {
+		var maxUID int
+		if err := models.NewQuery(qm.Select("coalesce(max(gateway_uid), 0) + 1"), qm.From(models.TableNames.Rooms)).
+			QueryRow(a.DB).Scan(&maxUID); err != nil {
+			httputil.NewInternalError(pkgerr.Wrap(err, "fetch max gateway_uid")).Abort(w, r)
+			return
+		}
+		data.GatewayUID = strconv.Itoa(maxUID)
+	} else if (gatewayUID, _ := strconv.Atoi(data.GatewayUID)) < 0 {
+		httputil.NewBadRequestError(nil, "gateway_uid must be a positive integer").Abort(w, r)
+		return
+	} else if _, ok := a.cache.rooms.ByGatewayUID(data.GatewayUID); ok {
+		httputil.NewBadRequestError(nil, "room already exists [gateway_uid]").Abort(w, r)
+		return
+	}
+
+	profile, hErr := a.findRoomProfileOf(&data)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	saga := domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeRooms), domain.CreateRoomSteps(data.GatewayUID, data.Name, profile)...)
+
+	err := a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		if err := data.Insert(tx, boil.Whitelist("name", "default_gateway_id", "gateway_uid", "disabled", "region", "room_profile_id")); err != nil {
 			return pkgerr.WithStack(err)
 		}
 		return nil
 	})
 
 	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
-		}
+		abortGatewaysSaga(w, r, err)
 		return
 	}
 
-	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
+	if err := a.cache.rooms.Reload(a.DB); err != nil {
 		log.Error().Err(err).Msg("Reload cache")
 	}
 
-	httputil.RespondWithJSON(w, http.StatusCreated, data)
+	httputil.RespondWithJSON(w, http.StatusCreated, RoomResponse{Room: &data, Gateways: saga.Results})
 }
 
-func (a *App) AdminGetDynamicConfig(w http.ResponseWriter, r *http.Request) {
+func (a *App) AdminGetRoom(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
@@ -670,7 +855,7 @@ func (a *App) AdminGetDynamicConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	kv, err := models.FindDynamicConfig(a.DB, id)
+	room, err := models.FindRoom(a.DB, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			httputil.NewNotFoundError().Abort(w, r)
@@ -680,10 +865,10 @@ func (a *App) AdminGetDynamicConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, kv)
+	httputil.RespondWithJSON(w, http.StatusOK, room)
 }
 
-func (a *App) AdminUpdateDynamicConfig(w http.ResponseWriter, r *http.Request) {
+func (a *App) AdminUpdateRoom(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
@@ -696,7 +881,7 @@ func (a *App) AdminUpdateDynamicConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	kv, err := models.FindDynamicConfig(a.DB, id)
+	room, err := models.FindRoom(a.DB, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			httputil.NewNotFoundError().Abort(w, r)
@@ -706,155 +891,2016 @@ func (a *App) AdminUpdateDynamicConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var data models.DynamicConfig
+	var data models.Room
 	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
 		err.Abort(w, r)
 		return
 	}
 	a.requestContext(r).Params = data
 
-	if len(data.Key) == 0 || len(data.Key) > 255 {
-		httputil.NewBadRequestError(nil, "key is missing or longer than 255 characters").Abort(w, r)
+	if data.GatewayUID <= 0 {
+		httputil.NewBadRequestError(nil, "gateway_uid must be a positive integer").Abort(w, r)
 		return
 	}
 
-	if len(data.Value) == 0 {
-		httputil.NewBadRequestError(nil, "value is missing").Abort(w, r)
+	if len(data.Name) == 0 || len(data.Name) > 64 {
+		httputil.NewBadRequestError(nil, "name is missing or longer than 64 characters").Abort(w, r)
 		return
 	}
 
-	if exists, _ := models.DynamicConfigs(models.DynamicConfigWhere.Key.EQ(data.Key)).Exists(a.DB); exists {
-		httputil.NewBadRequestError(nil, "key already exists").Abort(w, r)
-		return
+	if _, ok := a.cache.gateways.ByID(data.DefaultGatewayID); !ok {
+		httputil.NewBadRequestError(nil, "gateway doesn't exists").Abort(w, r)
+		return
 	}
 
-	err = sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		kv.Key = data.Key
-		kv.Value = data.Value
-		kv.UpdatedAt = time.Now().UTC()
-		if _, err := kv.Update(tx, boil.Whitelist("key", "value", "updated_at")); err != nil {
+	if exists, _ := models.Rooms(models.RoomWhere.GatewayUID.EQ(data.GatewayUID), models.RoomWhere.ID.NEQ(room.ID)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "room already exists [gateway_uid]").Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.Rooms(models.RoomWhere.Name.EQ(data.Name), models.RoomWhere.ID.NEQ(room.ID)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "room already exists [name]").Abort(w, r)
+		return
+	}
+
+	oldProfile, hErr := a.findRoomProfileOf(room)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+	profile, hErr := a.findRoomProfileOf(&data)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	var saga *domain.GatewaysSaga
+	if (room.Name != data.Name || room.RoomProfileID != data.RoomProfileID) && !room.RemovedAt.Valid {
+		saga = domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeRooms),
+			domain.EditRoomSteps(data.GatewayUID, room.Name, oldProfile, data.Name, profile)...)
+	}
+
+	err = a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		room.Name = data.Name
+		room.DefaultGatewayID = data.DefaultGatewayID
+		room.Disabled = data.Disabled
+		room.Region = data.Region
+		room.RoomProfileID = data.RoomProfileID
+		room.UpdatedAt = null.TimeFrom(time.Now().UTC())
+		if _, err := room.Update(tx, boil.Whitelist("name", "default_gateway_id", "disabled", "region", "room_profile_id", "updated_at")); err != nil {
 			return pkgerr.WithStack(err)
 		}
-
 		return nil
 	})
 
 	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
-		}
+		abortGatewaysSaga(w, r, err)
 		return
 	}
 
-	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
+	if err := a.cache.rooms.Reload(a.DB); err != nil {
 		log.Error().Err(err).Msg("Reload cache")
 	}
 
-	httputil.RespondWithJSON(w, http.StatusOK, kv)
+	resp := RoomResponse{Room: room}
+	if saga != nil {
+		resp.Gateways = saga.Results
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
 }
 
-func (a *App) AdminSetDynamicConfig(w http.ResponseWriter, r *http.Request) {
+func (a *App) AdminDeleteRoom(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
 	vars := mux.Vars(r)
-	key := vars["key"]
-	kv, ok := a.cache.dynamicConfig.ByKey(key)
-	if !ok {
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
 		httputil.NewNotFoundError().Abort(w, r)
 		return
 	}
 
-	var data models.DynamicConfig
+	room, err := models.FindRoom(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	profile, hErr := a.findRoomProfileOf(room)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	saga := domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeRooms), domain.DestroyRoomSteps(room.GatewayUID, room.Name, profile)...)
+
+	err = a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		room.RemovedAt = null.TimeFrom(time.Now().UTC())
+		if _, err := room.Update(tx, boil.Whitelist(models.RoomColumns.RemovedAt)); err != nil {
+			return httputil.NewInternalError(pkgerr.WithStack(err))
+		}
+		return nil
+	})
+
+	if err != nil {
+		abortGatewaysSaga(w, r, err)
+		return
+	}
+
+	if err := a.cache.rooms.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, GatewaysSagaResponse{Result: "success", Gateways: saga.Results})
+}
+
+// findRoomProfileOf returns the profile the room references, the default profile if none
+func (a *App) findRoomProfileOf(room *models.Room) (*models.RoomProfile, *httputil.HttpError) {
+	profile, err := domain.FindRoomProfile(a.DB, room)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httputil.NewBadRequestError(nil, "room profile doesn't exists")
+		}
+		return nil, httputil.NewInternalError(err)
+	}
+	return profile, nil
+}
+
+// enabledGateways are the gateways of the given type our rooms or mountpoints should be on
+func (a *App) enabledGateways(gType string) []*models.Gateway {
+	gateways := make([]*models.Gateway, 0)
+	for _, gateway := range a.cache.gateways.Values() {
+		if gateway.Disabled || gateway.RemovedAt.Valid || gateway.Type != gType {
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+	return gateways
+}
+
+// runGatewaysSaga runs the saga on gateways and then the DB changes in a transaction.
+// The transaction isn't held open while waiting on gateways, the saga is compensated if the DB changes fail.
+// A nil saga only runs the DB changes.
+func (a *App) runGatewaysSaga(r *http.Request, saga *domain.GatewaysSaga, fn func(tx *sql.Tx) error) error {
+	if saga != nil {
+		if err := saga.Run(); err != nil {
+			return err
+		}
+	}
+
+	err := sqlutil.InTx(r.Context(), a.DB, fn)
+	if err != nil && saga != nil {
+		saga.Compensate()
+	}
+	return err
+}
+
+// abortGatewaysSaga responds with the per gateway results of a failed gateways saga, if that's what failed
+func abortGatewaysSaga(w http.ResponseWriter, r *http.Request, err error) {
+	var sagaErr *domain.GatewaysSagaError
+	if errors.As(err, &sagaErr) {
+		hlog.FromRequest(r).Error().Err(err).Msg("gateways saga failed")
+		httputil.RespondWithJSON(w, http.StatusBadGateway, GatewaysSagaResponse{
+			Error:    "operation failed on gateways",
+			Gateways: sagaErr.Results,
+		})
+		return
+	}
+
+	var hErr *httputil.HttpError
+	if errors.As(err, &hErr) {
+		hErr.Abort(w, r)
+	} else {
+		httputil.NewInternalError(err).Abort(w, r)
+	}
+}
+
+// AdminSyncRooms reconciles the videorooms and textrooms on rooms gateways with the rooms table
+func (a *App) AdminSyncRooms(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	dryRun, err := parseBoolParam(r.URL.Query(), "dry_run")
+	if err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
+	}
+
+	report, err := a.roomsSyncer.Sync(dryRun.Bool)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func (a *App) AdminListRoomProfiles(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	profiles, err := models.RoomProfiles(qm.OrderBy(models.RoomProfileColumns.Name)).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+	if profiles == nil {
+		profiles = make(models.RoomProfileSlice, 0)
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, RoomProfilesResponse{
+		ListResponse: ListResponse{
+			Total: int64(len(profiles)),
+		},
+		Items:   profiles,
+		Default: domain.DefaultRoomProfile,
+	})
+}
+
+func (a *App) AdminCreateRoomProfile(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	var data models.RoomProfile
 	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
 		err.Abort(w, r)
 		return
 	}
 	a.requestContext(r).Params = data
 
-	if len(data.Value) == 0 {
-		httputil.NewBadRequestError(nil, "value is missing").Abort(w, r)
+	if err := validateRoomProfile(&data); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
 		return
 	}
 
-	err := sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		kv.Value = data.Value
-		kv.UpdatedAt = time.Now().UTC()
-		if _, err := kv.Update(tx, boil.Whitelist("value", "updated_at")); err != nil {
+	if exists, _ := models.RoomProfiles(models.RoomProfileWhere.Name.EQ(data.Name)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "room profile already exists [name]").Abort(w, r)
+		return
+	}
+
+	if err := data.Insert(a.DB, boil.Blacklist("id", "created_at", "updated_at")); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, data)
+}
+
+func (a *App) AdminGetRoomProfile(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	profile, hErr := a.findRoomProfile(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, profile)
+}
+
+// AdminUpdateRoomProfile updates the profile and edits the videorooms of all the rooms using it on rooms gateways.
+// Codecs and RTP extensions only apply to videorooms created afterwards (or by janus-config).
+func (a *App) AdminUpdateRoomProfile(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	profile, hErr := a.findRoomProfile(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	var data models.RoomProfile
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if err := validateRoomProfile(&data); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.RoomProfiles(models.RoomProfileWhere.Name.EQ(data.Name), models.RoomProfileWhere.ID.NEQ(profile.ID)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "room profile already exists [name]").Abort(w, r)
+		return
+	}
+
+	rooms, err := models.Rooms(
+		models.RoomWhere.RoomProfileID.EQ(null.Int64From(profile.ID)),
+		models.RoomWhere.RemovedAt.IsNull(),
+	).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	oldProfile := *profile
+	data.ID = profile.ID
+	data.CreatedAt = profile.CreatedAt
+	data.UpdatedAt = null.TimeFrom(time.Now().UTC())
+
+	steps := make([]*domain.GatewaysSagaStep, 0, 2*len(rooms))
+	for _, room := range rooms {
+		steps = append(steps, domain.EditRoomSteps(room.GatewayUID, room.Name, &oldProfile, room.Name, &data)...)
+	}
+	var saga *domain.GatewaysSaga
+	if len(rooms) > 0 {
+		saga = domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeRooms), steps...)
+	}
+
+	err = a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		if _, err := data.Update(tx, boil.Blacklist("id", "created_at")); err != nil {
 			return pkgerr.WithStack(err)
 		}
-
 		return nil
 	})
 
 	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
+		abortGatewaysSaga(w, r, err)
+		return
+	}
+
+	resp := RoomProfileResponse{RoomProfile: &data}
+	if saga != nil {
+		resp.Gateways = saga.Results
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (a *App) AdminDeleteRoomProfile(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	profile, hErr := a.findRoomProfile(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.Rooms(models.RoomWhere.RoomProfileID.EQ(null.Int64From(profile.ID))).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "room profile is in use").Abort(w, r)
+		return
+	}
+
+	if _, err := profile.Delete(a.DB); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	httputil.RespondSuccess(w)
+}
+
+func (a *App) findRoomProfile(r *http.Request) (*models.RoomProfile, *httputil.HttpError) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, httputil.NewNotFoundError()
+	}
+
+	profile, err := models.FindRoomProfile(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httputil.NewNotFoundError()
 		}
+		return nil, httputil.NewInternalError(pkgerr.WithStack(err))
+	}
+
+	return profile, nil
+}
+
+func (a *App) AdminListMountpoints(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	mountpoints, err := models.Mountpoints(qm.OrderBy(models.MountpointColumns.GatewayUID)).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+	if mountpoints == nil {
+		mountpoints = make(models.MountpointSlice, 0)
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, MountpointsResponse{
+		ListResponse: ListResponse{
+			Total: int64(len(mountpoints)),
+		},
+		Items: mountpoints,
+	})
+}
+
+func (a *App) AdminCreateMountpoint(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	var data models.Mountpoint
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if err := validateMountpoint(&data); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.Mountpoints(models.MountpointWhere.Name.EQ(data.Name)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "mountpoint already exists [name]").Abort(w, r)
+		return
+	}
+
+	if data.GatewayUID == 0 {
+		var maxUID int
+		if err := models.NewQuery(qm.Select("coalesce(max(gateway_uid), 0) + 1"), qm.From(models.TableNames.Mountpoints)).
+			QueryRow(a.DB).Scan(&maxUID); err != nil {
+			httputil.NewInternalError(pkgerr.Wrap(err, "fetch max gateway_uid")).Abort(w, r)
+			return
+		}
+		data.GatewayUID = maxUID
+	} else if exists, _ := models.Mountpoints(models.MountpointWhere.GatewayUID.EQ(data.GatewayUID)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "mountpoint already exists [gateway_uid]").Abort(w, r)
+		return
+	}
+
+	// disabled mountpoints are kept off gateways,
+	// a mountpoint which already exists on a gateway is adopted if it has the same media settings.
+	var saga *domain.GatewaysSaga
+	if !data.Disabled {
+		saga = domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeStreaming), domain.CreateMountpointSteps(&data)...)
+	}
+
+	err := a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		if err := data.Insert(tx, boil.Blacklist("id", "created_at", "updated_at")); err != nil {
+			return pkgerr.WithStack(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		abortGatewaysSaga(w, r, err)
+		return
+	}
+
+	if err := a.cache.mountpoints.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	resp := MountpointResponse{Mountpoint: &data}
+	if saga != nil {
+		resp.Gateways = saga.Results
+	}
+	httputil.RespondWithJSON(w, http.StatusCreated, resp)
+}
+
+func (a *App) AdminGetMountpoint(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	mountpoint, hErr := a.findMountpoint(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, mountpoint)
+}
+
+func (a *App) AdminUpdateMountpoint(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	mountpoint, hErr := a.findMountpoint(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	var data models.Mountpoint
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if err := validateMountpoint(&data); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
+	}
+
+	if data.GatewayUID <= 0 {
+		httputil.NewBadRequestError(nil, "gateway_uid must be a positive integer").Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.Mountpoints(models.MountpointWhere.GatewayUID.EQ(data.GatewayUID), models.MountpointWhere.ID.NEQ(mountpoint.ID)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "mountpoint already exists [gateway_uid]").Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.Mountpoints(models.MountpointWhere.Name.EQ(data.Name), models.MountpointWhere.ID.NEQ(mountpoint.ID)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "mountpoint already exists [name]").Abort(w, r)
+		return
+	}
+
+	// recreating a mountpoint cuts its live viewers, make sure that's what the caller wants
+	if domain.MountpointRecreated(mountpoint, &data) && r.URL.Query().Get("recreate") != "true" {
+		httputil.NewHttpError(http.StatusConflict, nil,
+			"changes recreate the mountpoint on gateways, cutting live viewers. Set recreate=true to confirm").Abort(w, r)
+		return
+	}
+
+	data.ID = mountpoint.ID
+	data.CreatedAt = mountpoint.CreatedAt
+	data.UpdatedAt = null.TimeFrom(time.Now().UTC())
+
+	var saga *domain.GatewaysSaga
+	if steps := domain.EditMountpointSteps(mountpoint, &data); len(steps) > 0 {
+		saga = domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeStreaming), steps...)
+	}
+
+	err := a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		if _, err := data.Update(tx, boil.Blacklist("id", "created_at")); err != nil {
+			return pkgerr.WithStack(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		abortGatewaysSaga(w, r, err)
+		return
+	}
+
+	if err := a.cache.mountpoints.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	resp := MountpointResponse{Mountpoint: &data}
+	if saga != nil {
+		resp.Gateways = saga.Results
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (a *App) AdminDeleteMountpoint(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	mountpoint, hErr := a.findMountpoint(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	// disabled mountpoints are not on gateways
+	var saga *domain.GatewaysSaga
+	if !mountpoint.Disabled {
+		saga = domain.NewGatewaysSaga(a.enabledGateways(common.GatewayTypeStreaming), domain.DestroyMountpointSteps(mountpoint)...)
+	}
+
+	err := a.runGatewaysSaga(r, saga, func(tx *sql.Tx) error {
+		if _, err := mountpoint.Delete(tx); err != nil {
+			return httputil.NewInternalError(pkgerr.WithStack(err))
+		}
+		return nil
+	})
+
+	if err != nil {
+		abortGatewaysSaga(w, r, err)
+		return
+	}
+
+	if err := a.cache.mountpoints.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	resp := GatewaysSagaResponse{Result: "success"}
+	if saga != nil {
+		resp.Gateways = saga.Results
+	}
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (a *App) findMountpoint(r *http.Request) (*models.Mountpoint, *httputil.HttpError) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, httputil.NewNotFoundError()
+	}
+
+	mountpoint, err := models.FindMountpoint(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httputil.NewNotFoundError()
+		}
+		return nil, httputil.NewInternalError(pkgerr.WithStack(err))
+	}
+
+	return mountpoint, nil
+}
+
+func (a *App) AdminDeleteRoomsStatistics(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleShidur, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	if err := a.roomsStatisticsManager.Reset(r.Context()); err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondSuccess(w)
+}
+
+func (a *App) AdminListDynamicConfigs(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	listParams, err := ParseListParams(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+		return
+	}
+
+	mods := make([]qm.QueryMod, 0)
+
+	// count query
+	var total int64
+	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
+	err = models.DynamicConfigs(countMods...).QueryRow(a.DB).Scan(&total)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	} else if total == 0 {
+		httputil.RespondWithJSON(w, http.StatusOK, DynamicConfigsResponse{Items: make([]*models.DynamicConfig, 0)})
+		return
+	}
+
+	// order, limit, offset
+	if listParams.OrderBy == "" {
+		listParams.OrderBy = "key asc"
+	}
+	_, offset := listParams.appendListMods(&mods)
+	if int64(offset) >= total {
+		httputil.RespondWithJSON(w, http.StatusOK, DynamicConfigsResponse{Items: make([]*models.DynamicConfig, 0)})
+		return
+	}
+
+	// data query
+	kvs, err := models.DynamicConfigs(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, DynamicConfigsResponse{
+		ListResponse: ListResponse{
+			Total: total,
+		},
+		Items: kvs,
+	})
+}
+
+func (a *App) AdminCreateDynamicConfig(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	var data models.DynamicConfig
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if len(data.Key) == 0 || len(data.Key) > 255 {
+		httputil.NewBadRequestError(nil, "key is missing or longer than 255 characters").Abort(w, r)
+		return
+	}
+
+	if len(data.Value) == 0 {
+		httputil.NewBadRequestError(nil, "value is missing").Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.DynamicConfigs(models.DynamicConfigWhere.Key.EQ(data.Key)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "key already exists").Abort(w, r)
+		return
+	}
+
+	err := sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
+		data.UpdatedAt = time.Now().UTC()
+		if err := data.Insert(tx, boil.Whitelist("key", "value", "updated_at")); err != nil {
+			return pkgerr.WithStack(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		var hErr *httputil.HttpError
+		if errors.As(err, &hErr) {
+			hErr.Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, data)
+}
+
+func (a *App) AdminGetDynamicConfig(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	kv, err := models.FindDynamicConfig(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, kv)
+}
+
+func (a *App) AdminUpdateDynamicConfig(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	kv, err := models.FindDynamicConfig(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	var data models.DynamicConfig
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if len(data.Key) == 0 || len(data.Key) > 255 {
+		httputil.NewBadRequestError(nil, "key is missing or longer than 255 characters").Abort(w, r)
+		return
+	}
+
+	if len(data.Value) == 0 {
+		httputil.NewBadRequestError(nil, "value is missing").Abort(w, r)
+		return
+	}
+
+	if exists, _ := models.DynamicConfigs(models.DynamicConfigWhere.Key.EQ(data.Key)).Exists(a.DB); exists {
+		httputil.NewBadRequestError(nil, "key already exists").Abort(w, r)
+		return
+	}
+
+	err = sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
+		kv.Key = data.Key
+		kv.Value = data.Value
+		kv.UpdatedAt = time.Now().UTC()
+		if _, err := kv.Update(tx, boil.Whitelist("key", "value", "updated_at")); err != nil {
+			return pkgerr.WithStack(err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		var hErr *httputil.HttpError
+		if errors.As(err, &hErr) {
+			hErr.Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, kv)
+}
+
+func (a *App) AdminSetDynamicConfig(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	key := vars["key"]
+	kv, ok := a.cache.dynamicConfig.ByKey(key)
+	if !ok {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	var data models.DynamicConfig
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if len(data.Value) == 0 {
+		httputil.NewBadRequestError(nil, "value is missing").Abort(w, r)
+		return
+	}
+
+	err := sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
+		kv.Value = data.Value
+		kv.UpdatedAt = time.Now().UTC()
+		if _, err := kv.Update(tx, boil.Whitelist("value", "updated_at")); err != nil {
+			return pkgerr.WithStack(err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		var hErr *httputil.HttpError
+		if errors.As(err, &hErr) {
+			hErr.Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	httputil.RespondSuccess(w)
+}
+
+func (a *App) AdminDeleteDynamicConfig(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	kv, err := models.FindDynamicConfig(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	err = sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
+		if _, err := kv.Delete(tx); err != nil {
+			return httputil.NewInternalError(pkgerr.WithStack(err))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		var hErr *httputil.HttpError
+		if errors.As(err, &hErr) {
+			hErr.Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
+		log.Error().Err(err).Msg("Reload cache")
+	}
+
+	httputil.RespondSuccess(w)
+}
+
+func (a *App) AdminGetReconcileReport(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	report := a.sessionReconciler.LastReport()
+	if report == nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, report)
+}
+
+func (a *App) AdminReconcile(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, a.sessionReconciler.Reconcile())
+}
+
+func (a *App) AdminListUsers(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	listParams, err := ParseListParams(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+		return
+	}
+
+	usersRequest, err := ParseUsersRequest(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed users request parameters").Abort(w, r)
+		return
+	}
+
+	mods := make([]qm.QueryMod, 0)
+
+	// filters
+	if usersRequest.Disabled.Valid {
+		mods = append(mods, models.UserWhere.Disabled.EQ(usersRequest.Disabled.Bool))
+	}
+	if usersRequest.Removed.Valid {
+		if usersRequest.Removed.Bool {
+			mods = append(mods, models.UserWhere.RemovedAt.IsNotNull())
+		} else {
+			mods = append(mods, models.UserWhere.RemovedAt.IsNull())
+		}
+	}
+	if len(usersRequest.Term) > 0 {
+		var clauses []string
+		var args []interface{}
+
+		// numeric value ?
+		if numVal, err := strconv.ParseUint(usersRequest.Term, 10, 64); err == nil {
+			clauses = append(clauses, fmt.Sprintf("%s = ?", models.UserColumns.ID))
+			args = append(args, numVal)
+		}
+
+		clauses = append(clauses,
+			fmt.Sprintf("%s = ?", models.UserColumns.AccountsID),
+			"email ~* ?",
+			"username ~* ?",
+			"concat_ws(' ', first_name, last_name) ~* ?")
+		args = append(args, usersRequest.Term, usersRequest.Term, usersRequest.Term, usersRequest.Term)
+
+		mods = append(mods, qm.Where(strings.Join(clauses, " OR "), args...))
+	}
+
+	// count query
+	var total int64
+	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
+	err = models.Users(countMods...).QueryRow(a.DB).Scan(&total)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	} else if total == 0 {
+		httputil.RespondWithJSON(w, http.StatusOK, UsersResponse{Users: make([]*models.User, 0)})
+		return
+	}
+
+	// order, limit, offset
+	_, offset := listParams.appendListMods(&mods)
+	if int64(offset) >= total {
+		httputil.RespondWithJSON(w, http.StatusOK, UsersResponse{Users: make([]*models.User, 0)})
+		return
+	}
+
+	// data query
+	users, err := models.Users(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, UsersResponse{
+		ListResponse: ListResponse{
+			Total: total,
+		},
+		Users: users,
+	})
+}
+
+func (a *App) AdminGetUser(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	user, hErr := a.findUser(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, user)
+}
+
+func (a *App) AdminUpdateUser(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	user, hErr := a.findUser(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	var data UpdateUserRequest
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if data.Email != nil && len(*data.Email) > 255 {
+		httputil.NewBadRequestError(nil, "email is longer than 255 characters").Abort(w, r)
+		return
+	}
+
+	cols := make([]string, 0)
+	setString := func(field *null.String, value *string, col string) {
+		if value != nil {
+			*field = null.NewString(*value, *value != "")
+			cols = append(cols, col)
+		}
+	}
+	setString(&user.FirstName, data.FirstName, models.UserColumns.FirstName)
+	setString(&user.LastName, data.LastName, models.UserColumns.LastName)
+	setString(&user.Email, data.Email, models.UserColumns.Email)
+	setString(&user.Username, data.Username, models.UserColumns.Username)
+	if data.Disabled != nil {
+		user.Disabled = *data.Disabled
+		cols = append(cols, models.UserColumns.Disabled)
+	}
+
+	if len(cols) == 0 {
+		httputil.RespondWithJSON(w, http.StatusOK, user)
+		return
+	}
+
+	user.UpdatedAt = null.TimeFrom(time.Now().UTC())
+	cols = append(cols, models.UserColumns.UpdatedAt)
+	if _, err := user.Update(a.DB, boil.Whitelist(cols...)); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	a.cache.users.Remove(user.AccountsID)
+
+	httputil.RespondWithJSON(w, http.StatusOK, user)
+}
+
+func (a *App) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	user, hErr := a.findUser(r)
+	if hErr != nil {
+		hErr.Abort(w, r)
+		return
+	}
+
+	user.RemovedAt = null.TimeFrom(time.Now().UTC())
+	if _, err := user.Update(a.DB, boil.Whitelist(models.UserColumns.RemovedAt)); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	a.cache.users.Remove(user.AccountsID)
+
+	httputil.RespondSuccess(w)
+}
+
+// findUser fetches the user of the {id} path parameter
+func (a *App) findUser(r *http.Request) (*models.User, *httputil.HttpError) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		return nil, httputil.NewNotFoundError()
+	}
+
+	user, err := models.FindUser(a.DB, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httputil.NewNotFoundError()
+		}
+		return nil, httputil.NewInternalError(pkgerr.WithStack(err))
+	}
+
+	return user, nil
+}
+
+func (a *App) AdminListBans(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	listParams, err := ParseListParams(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+		return
+	}
+
+	bansRequest, err := ParseBansRequest(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed bans request parameters").Abort(w, r)
+		return
+	}
+
+	mods := make([]qm.QueryMod, 0)
+
+	// filters
+	if len(bansRequest.Users) > 0 {
+		mods = append(mods, models.UserBanWhere.UserID.IN(bansRequest.Users))
+	}
+	if len(bansRequest.Rooms) > 0 {
+		mods = append(mods, qm.Where("room_id = ANY(?)", pq.Array(bansRequest.Rooms)))
+	}
+	if bansRequest.Active.Valid {
+		if bansRequest.Active.Bool {
+			mods = append(mods,
+				models.UserBanWhere.LiftedAt.IsNull(),
+				models.UserBanWhere.Until.GT(time.Now().UTC()))
+		} else {
+			mods = append(mods, qm.Where("lifted_at is not null or until <= ?", time.Now().UTC()))
+		}
+	}
+
+	// count query
+	var total int64
+	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
+	err = models.UserBans(countMods...).QueryRow(a.DB).Scan(&total)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	} else if total == 0 {
+		httputil.RespondWithJSON(w, http.StatusOK, BansResponse{Bans: make([]*models.UserBan, 0)})
+		return
+	}
+
+	// order, limit, offset
+	_, offset := listParams.appendListMods(&mods)
+	if int64(offset) >= total {
+		httputil.RespondWithJSON(w, http.StatusOK, BansResponse{Bans: make([]*models.UserBan, 0)})
+		return
+	}
+
+	// data query
+	bans, err := models.UserBans(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, BansResponse{
+		ListResponse: ListResponse{
+			Total: total,
+		},
+		Bans: bans,
+	})
+}
+
+func (a *App) AdminCreateBan(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	var data BanRequest
+	if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+		err.Abort(w, r)
+		return
+	}
+	a.requestContext(r).Params = data
+
+	if err := data.Validate(); err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
+	}
+
+	exists, err := models.UserExists(a.DB, data.UserID)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	} else if !exists {
+		httputil.NewBadRequestError(nil, "user doesn't exists").Abort(w, r)
+		return
+	}
+
+	if data.RoomID.Valid {
+		exists, err := models.RoomExists(a.DB, data.RoomID.Int64)
+		if err != nil {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+			return
+		} else if !exists {
+			httputil.NewBadRequestError(nil, "room doesn't exists").Abort(w, r)
+			return
+		}
+	}
+
+	var by string
+	if rCtx := a.requestContext(r); rCtx.IDClaims != nil {
+		by = rCtx.IDClaims.Sub
+	}
+
+	ban := &models.UserBan{
+		UserID:   data.UserID,
+		RoomID:   data.RoomID,
+		Reason:   null.NewString(data.Reason, data.Reason != ""),
+		BannedBy: null.NewString(by, by != ""),
+		Until:    data.Until.UTC(),
+	}
+	if data.Minutes > 0 {
+		ban.Until = time.Now().UTC().Add(time.Duration(data.Minutes) * time.Minute)
+	}
+
+	if err := a.createUserBan(ban); err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusCreated, ban)
+}
+
+func (a *App) AdminLiftBan(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	ban, err := models.UserBans(
+		models.UserBanWhere.ID.EQ(id),
+		models.UserBanWhere.LiftedAt.IsNull(),
+	).One(a.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	var by string
+	if rCtx := a.requestContext(r); rCtx.IDClaims != nil {
+		by = rCtx.IDClaims.Sub
+	}
+
+	ban.LiftedAt = null.TimeFrom(time.Now().UTC())
+	ban.LiftedBy = null.NewString(by, by != "")
+	if _, err := ban.Update(a.DB, boil.Whitelist(models.UserBanColumns.LiftedAt, models.UserBanColumns.LiftedBy)); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	if err := a.cache.bans.Reload(a.DB); err != nil {
+		httputil.NewInternalError(pkgerr.WithMessage(err, "reload bans cache")).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, ban)
+}
+
+func (a *App) createUserBan(ban *models.UserBan) error {
+	if err := ban.Insert(a.DB, boil.Infer()); err != nil {
+		return pkgerr.Wrap(err, "db insert user ban")
+	}
+
+	if err := a.cache.bans.Reload(a.DB); err != nil {
+		return pkgerr.WithMessage(err, "reload bans cache")
+	}
+
+	return nil
+}
+
+func (a *App) AdminListSessions(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	listParams, err := ParseListParams(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+		return
+	}
+
+	sessionsRequest, err := ParseSessionsRequest(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed sessions request parameters").Abort(w, r)
+		return
+	}
+
+	mods := make([]qm.QueryMod, 0)
+
+	// filters
+	if len(sessionsRequest.Rooms) > 0 {
+		mods = append(mods, qm.Where("room_id = ANY(?)", pq.Array(sessionsRequest.Rooms)))
+	}
+	if len(sessionsRequest.Gateways) > 0 {
+		mods = append(mods, qm.Where("gateway_id = ANY(?)", pq.Array(sessionsRequest.Gateways)))
+	}
+	if len(sessionsRequest.AccountsIDs) > 0 {
+		mods = append(mods, qm.Where("user_id IN (SELECT id FROM users WHERE accounts_id = ANY(?))",
+			pq.Array(sessionsRequest.AccountsIDs)))
+	}
+	if sessionsRequest.Removed.Valid {
+		if sessionsRequest.Removed.Bool {
+			mods = append(mods, models.SessionWhere.RemovedAt.IsNotNull())
+		} else {
+			mods = append(mods, models.SessionWhere.RemovedAt.IsNull())
+		}
+	}
+	if sessionsRequest.Camera.Valid {
+		mods = append(mods, models.SessionWhere.Camera.EQ(sessionsRequest.Camera.Bool))
+	}
+	if sessionsRequest.Question.Valid {
+		mods = append(mods, models.SessionWhere.Question.EQ(sessionsRequest.Question.Bool))
+	}
+	if sessionsRequest.IP != "" {
+		mods = append(mods, qm.Where("ip_address <<= ?::inet", sessionsRequest.IP))
+	}
+	if sessionsRequest.CreatedFrom.Valid {
+		mods = append(mods, models.SessionWhere.CreatedAt.GTE(sessionsRequest.CreatedFrom.Time))
+	}
+	if sessionsRequest.CreatedTo.Valid {
+		mods = append(mods, models.SessionWhere.CreatedAt.LT(sessionsRequest.CreatedTo.Time))
+	}
+	if sessionsRequest.RemovedFrom.Valid {
+		mods = append(mods, models.SessionWhere.RemovedAt.GTE(sessionsRequest.RemovedFrom))
+	}
+	if sessionsRequest.RemovedTo.Valid {
+		mods = append(mods, models.SessionWhere.RemovedAt.LT(sessionsRequest.RemovedTo))
+	}
+
+	// count query
+	var total int64
+	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
+	err = models.Sessions(countMods...).QueryRow(a.DB).Scan(&total)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	} else if total == 0 {
+		httputil.RespondWithJSON(w, http.StatusOK, SessionsResponse{Sessions: make([]*SessionDTO, 0)})
+		return
+	}
+
+	// order, limit, offset
+	_, offset := listParams.appendListMods(&mods)
+	if int64(offset) >= total {
+		httputil.RespondWithJSON(w, http.StatusOK, SessionsResponse{Sessions: make([]*SessionDTO, 0)})
+		return
+	}
+
+	// data query
+	mods = append(mods,
+		qm.Load(models.SessionRels.User),
+		qm.Load(models.SessionRels.Room),
+		qm.Load(models.SessionRels.Gateway),
+	)
+	sessions, err := models.Sessions(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	data := make([]*SessionDTO, len(sessions))
+	for i := range sessions {
+		data[i] = NewSessionDTO(sessions[i])
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, SessionsResponse{
+		ListResponse: ListResponse{
+			Total: total,
+		},
+		Sessions: data,
+	})
+}
+
+func (a *App) AdminKickSession(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleShidur, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	session, err := models.Sessions(
+		models.SessionWhere.ID.EQ(id),
+		qm.Load(models.SessionRels.Room),
+	).One(a.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	if session.RemovedAt.Valid {
+		httputil.NewBadRequestError(nil, "session is not active").Abort(w, r)
+		return
+	}
+
+	a.kickSessions(w, r, session.UserID, session.RoomID, false, models.SessionSlice{session})
+}
+
+func (a *App) AdminKickUser(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleShidur, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	user, err := models.Users(models.UserWhere.AccountsID.EQ(vars["accounts_id"])).One(a.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	sessions, err := models.Sessions(
+		models.SessionWhere.UserID.EQ(user.ID),
+		models.SessionWhere.RemovedAt.IsNull(),
+		qm.Load(models.SessionRels.Room),
+	).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	if len(sessions) == 0 {
+		httputil.NewBadRequestError(nil, "user has no active sessions").Abort(w, r)
+		return
+	}
+
+	a.kickSessions(w, r, user.ID, null.Int64{}, true, sessions)
+}
+
+// kickSessions kicks the given sessions out of their gateway's videoroom and closes them.
+// Sessions which couldn't be kicked are left open and reported with a 502.
+// An optional re-entry block is set on the user for roomID, or for all rooms if allRooms.
+// The block is only set if any session was kicked.
+func (a *App) kickSessions(w http.ResponseWriter, r *http.Request, userID int64, roomID null.Int64, allRooms bool, sessions models.SessionSlice) {
+	var data KickRequest
+	if r.ContentLength != 0 {
+		if err := httputil.DecodeJSONBody(w, r, &data); err != nil {
+			err.Abort(w, r)
+			return
+		}
+	}
+	a.requestContext(r).Params = data
+
+	if data.BlockMinutes < 0 {
+		httputil.NewBadRequestError(nil, "block_minutes must be a positive integer").Abort(w, r)
+		return
+	}
+	if data.BlockMinutes > 0 && !allRooms && !roomID.Valid {
+		httputil.NewBadRequestError(nil, "session has no room to block").Abort(w, r)
+		return
+	}
+
+	kicked := make([]int64, 0, len(sessions))
+	failed := make([]*KickSessionResult, 0)
+	for _, session := range sessions {
+		if err := a.kickFromGateway(session); err != nil {
+			hlog.FromRequest(r).Error().Err(err).Int64("session", session.ID).Msg("kick from gateway")
+			failed = append(failed, &KickSessionResult{Session: session.ID, Error: err.Error()})
+			continue
+		}
+		kicked = append(kicked, session.ID)
+	}
+
+	var by string
+	if rCtx := a.requestContext(r); rCtx.IDClaims != nil {
+		by = rCtx.IDClaims.Sub
+	}
+
+	props := map[string]interface{}{
+		"reason": "kick",
+		"by":     by,
+	}
+	if data.Comment != "" {
+		props["comment"] = data.Comment
+	}
+
+	if data.BlockMinutes > 0 && len(kicked) > 0 {
+		reason := "kick"
+		if data.Comment != "" {
+			reason = fmt.Sprintf("kick: %s", data.Comment)
+		}
+		ban := &models.UserBan{
+			UserID:   userID,
+			RoomID:   roomID,
+			Reason:   null.StringFrom(reason),
+			BannedBy: null.NewString(by, by != ""),
+			Until:    time.Now().UTC().Add(time.Duration(data.BlockMinutes) * time.Minute),
+		}
+		props["block_until"] = ban.Until
+
+		if err := a.createUserBan(ban); err != nil {
+			httputil.NewInternalError(err).Abort(w, r)
+			return
+		}
+	}
+
+	if err := a.sessionManager.CloseSessions(r.Context(), userID, kicked, common.SessionEventSourceAdmin, props); err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	if len(failed) > 0 {
+		httputil.RespondWithJSON(w, http.StatusBadGateway, KickResponse{
+			Error:  "kick failed on gateways",
+			Failed: failed,
+		})
+		return
+	}
+
+	httputil.RespondSuccess(w)
+}
+
+func (a *App) kickFromGateway(session *models.Session) error {
+	gateway, ok := a.cache.gateways.ByID(session.GatewayID.Int64)
+	if !ok || session.R == nil || session.R.Room == nil || !session.GatewayFeed.Valid {
+		// nobody could be removed from the gateway so we can't tell the session is gone
+		return pkgerr.New("session is missing gateway, room or feed")
+	}
+
+	api, err := domain.GatewayAdminAPIRegistry.For(gateway)
+	if err != nil {
+		return pkgerr.WithMessage(err, "Admin API for gateway")
+	}
+
+	request := domain.NewVideoroomKickRequest(common.Config.GatewayPluginAdminKey,
+		session.R.Room.GatewayUID, session.GatewayFeed.Int64, common.Config.GatewayRoomsSecret)
+	resp, err := domain.MessagePlugin(api, request)
+	if err != nil {
+		var vErr *janus_plugins.VideoroomErrorResponse
+		if errors.As(err, &vErr) {
+			return pkgerr.Errorf("videoroom kick refused [%d]: %s", vErr.Code, vErr.Reason)
+		}
+		return err
+	}
+	if done, ok := resp.(*domain.AlreadyDoneResponse); ok {
+		log.Warn().Int("code", done.Code).Str("reason", done.Reason).Int64("session", session.ID).
+			Msg("kick: participant is gone already")
+	}
+
+	return nil
+}
+
+func (a *App) AdminExportUser(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	data, err := a.userDataManager.Export(mux.Vars(r)["accounts_id"])
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, data)
+}
+
+func (a *App) AdminEraseUser(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	accountsID := mux.Vars(r)["accounts_id"]
+	user, err := models.Users(models.UserWhere.AccountsID.EQ(accountsID)).One(a.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	// close active sessions first so observers get to know the user left
+	if err := a.sessionManager.CloseSession(r.Context(), user.ID, common.SessionEventSourceAdmin, map[string]interface{}{
+		"reason": "erase",
+	}); err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	user, err = a.userDataManager.Erase(r.Context(), accountsID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	a.cache.users.Remove(accountsID)
+
+	httputil.RespondWithJSON(w, http.StatusOK, user)
+}
+
+func (a *App) AdminListSessionEvents(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	exists, err := models.SessionExists(a.DB, id)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	} else if !exists {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	a.listSessionEvents(w, r, models.SessionEventWhere.SessionID.EQ(id))
+}
+
+func (a *App) AdminListUserEvents(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	vars := mux.Vars(r)
+	user, err := models.Users(models.UserWhere.AccountsID.EQ(vars["accounts_id"])).One(a.DB)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		}
+		return
+	}
+
+	a.listSessionEvents(w, r, models.SessionEventWhere.UserID.EQ(user.ID))
+}
+
+func (a *App) listSessionEvents(w http.ResponseWriter, r *http.Request, mods ...qm.QueryMod) {
+	query := r.URL.Query()
+	listParams, err := ParseListParams(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+		return
+	}
+
+	eventsRequest, err := ParseSessionEventsRequest(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed session events request parameters").Abort(w, r)
+		return
+	}
+
+	// filters
+	if len(eventsRequest.Types) > 0 {
+		mods = append(mods, models.SessionEventWhere.Type.IN(eventsRequest.Types))
+	}
+	if len(eventsRequest.Sources) > 0 {
+		mods = append(mods, models.SessionEventWhere.Source.IN(eventsRequest.Sources))
+	}
+	if eventsRequest.From.Valid {
+		mods = append(mods, models.SessionEventWhere.CreatedAt.GTE(eventsRequest.From.Time))
+	}
+	if eventsRequest.To.Valid {
+		mods = append(mods, models.SessionEventWhere.CreatedAt.LT(eventsRequest.To.Time))
+	}
+
+	// count query
+	var total int64
+	countMods := append([]qm.QueryMod{qm.Select("count(DISTINCT id)")}, mods...)
+	err = models.SessionEvents(countMods...).QueryRow(a.DB).Scan(&total)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	} else if total == 0 {
+		httputil.RespondWithJSON(w, http.StatusOK, SessionEventsResponse{Items: make([]*models.SessionEvent, 0)})
+		return
+	}
+
+	// order, limit, offset
+	if listParams.OrderBy == "" {
+		listParams.OrderBy = "created_at asc, id asc"
+	}
+	_, offset := listParams.appendListMods(&mods)
+	if int64(offset) >= total {
+		httputil.RespondWithJSON(w, http.StatusOK, SessionEventsResponse{Items: make([]*models.SessionEvent, 0)})
+		return
+	}
+
+	// data query
+	events, err := models.SessionEvents(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, SessionEventsResponse{
+		ListResponse: ListResponse{
+			Total: total,
+		},
+		Items: events,
+	})
+}
+
+func (a *App) AdminAttendanceReport(w http.ResponseWriter, r *http.Request) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	listParams, err := ParseListParams(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed list parameters").Abort(w, r)
+		return
+	}
+
+	attendanceRequest, err := ParseAttendanceRequest(query)
+	if err != nil {
+		httputil.NewBadRequestError(err, "malformed attendance request parameters").Abort(w, r)
+		return
+	}
+
+	mods := []qm.QueryMod{
+		models.DailyAttendanceWhere.Date.GTE(attendanceRequest.From),
+		models.DailyAttendanceWhere.Date.LTE(attendanceRequest.To),
+	}
+	if len(attendanceRequest.Rooms) > 0 {
+		mods = append(mods, qm.Where("room_id = ANY(?)", pq.Array(attendanceRequest.Rooms)))
+	}
+	if len(attendanceRequest.Regions) > 0 {
+		mods = append(mods, qm.Where("region = ANY(?)", pq.Array(attendanceRequest.Regions)))
+	}
+
+	// summary
+	summary := &AttendanceSummary{Rooms: make([]*RoomAttendance, 0)}
+	summaryMods := append([]qm.QueryMod{qm.Select("count(DISTINCT user_id)", "coalesce(sum(total_minutes), 0)")}, mods...)
+	if err := models.DailyAttendances(summaryMods...).QueryRow(a.DB).Scan(&summary.DistinctUsers, &summary.TotalMinutes); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	roomsMods := append([]qm.QueryMod{
+		qm.Select("room_id", "count(DISTINCT user_id)", "sum(total_minutes)"),
+		qm.GroupBy("room_id"),
+		qm.OrderBy("2 desc"),
+	}, mods...)
+	rows, err := models.DailyAttendances(roomsMods...).Query.Query(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+	defer rows.Close()
+	for rows.Next() {
+		ra := new(RoomAttendance)
+		if err := rows.Scan(&ra.RoomID, &ra.DistinctUsers, &ra.TotalMinutes); err != nil {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+			return
+		}
+		summary.Rooms = append(summary.Rooms, ra)
+	}
+	if err := rows.Err(); err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+	roomsByID := make(map[int64]*models.Room)
+	for _, room := range a.cache.rooms.Values() {
+		roomsByID[room.ID] = room
+	}
+	for _, ra := range summary.Rooms {
+		if room, ok := roomsByID[ra.RoomID]; ok {
+			ra.Name = room.Name
+			ra.Region = room.Region.String
+		}
+	}
+
+	// data
+	csvExport := query.Get("format") == "csv"
+	var total int64
+	if !csvExport {
+		countMods := append([]qm.QueryMod{qm.Select("count(id)")}, mods...)
+		if err := models.DailyAttendances(countMods...).QueryRow(a.DB).Scan(&total); err != nil {
+			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+			return
+		}
+
+		if listParams.OrderBy == "" {
+			listParams.OrderBy = "date desc, total_minutes desc"
+		}
+		_, offset := listParams.appendListMods(&mods)
+		if int64(offset) >= total {
+			httputil.RespondWithJSON(w, http.StatusOK, AttendanceResponse{
+				ListResponse: ListResponse{Total: total},
+				Summary:      summary,
+				Items:        make([]*AttendanceDTO, 0),
+			})
+			return
+		}
+	} else {
+		mods = append(mods, qm.OrderBy("date asc, room_id asc, user_id asc"))
+	}
+
+	mods = append(mods,
+		qm.Load(models.DailyAttendanceRels.User),
+		qm.Load(models.DailyAttendanceRels.Room),
+	)
+	attendance, err := models.DailyAttendances(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
 		return
 	}
 
-	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
-		log.Error().Err(err).Msg("Reload cache")
+	data := make([]*AttendanceDTO, len(attendance))
+	for i := range attendance {
+		data[i] = NewAttendanceDTO(attendance[i])
 	}
 
-	httputil.RespondSuccess(w)
+	if csvExport {
+		writeAttendanceCSV(w, attendanceRequest, data)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, AttendanceResponse{
+		ListResponse: ListResponse{Total: total},
+		Summary:      summary,
+		Items:        data,
+	})
 }
 
-func (a *App) AdminDeleteDynamicConfig(w http.ResponseWriter, r *http.Request) {
+func (a *App) AdminRollupAttendance(w http.ResponseWriter, r *http.Request) {
 	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleRoot) {
 		httputil.NewForbiddenError().Abort(w, r)
 		return
 	}
 
-	vars := mux.Vars(r)
-	id, err := strconv.ParseInt(vars["id"], 10, 64)
-	if err != nil {
-		httputil.NewNotFoundError().Abort(w, r)
-		return
+	day := time.Now().UTC()
+	if strVal := r.URL.Query().Get("date"); strVal != "" {
+		var err error
+		if day, err = time.Parse(dateLayout, strVal); err != nil {
+			httputil.NewBadRequestError(err, "date must be YYYY-MM-DD").Abort(w, r)
+			return
+		}
 	}
 
-	kv, err := models.FindDynamicConfig(a.DB, id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			httputil.NewNotFoundError().Abort(w, r)
-		} else {
-			httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
-		}
+	if err := a.attendanceRollup.Rollup(day); err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
 
-	err = sqlutil.InTx(r.Context(), a.DB, func(tx *sql.Tx) error {
-		if _, err := kv.Delete(tx); err != nil {
-			return httputil.NewInternalError(pkgerr.WithStack(err))
-		}
-
-		return nil
-	})
+	httputil.RespondSuccess(w)
+}
 
-	if err != nil {
-		var hErr *httputil.HttpError
-		if errors.As(err, &hErr) {
-			hErr.Abort(w, r)
-		} else {
-			httputil.NewInternalError(err).Abort(w, r)
+func writeAttendanceCSV(w http.ResponseWriter, req *AttendanceRequest, data []*AttendanceDTO) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"attendance_%s_%s.csv\"",
+		req.From.Format(dateLayout), req.To.Format(dateLayout)))
+	w.WriteHeader(http.StatusOK)
+
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"date", "accounts_id", "email", "first_name", "last_name", "room_id", "room", "region",
+		"first_seen", "last_seen", "total_minutes", "sessions"})
+	for _, item := range data {
+		record := []string{
+			item.Date.Format(dateLayout),
+			"", "", "", "",
+			strconv.FormatInt(item.RoomID, 10),
+			"",
+			item.Region.String,
+			item.FirstSeen.UTC().Format(time.RFC3339),
+			item.LastSeen.UTC().Format(time.RFC3339),
+			strconv.Itoa(item.TotalMinutes),
+			strconv.Itoa(item.Sessions),
+		}
+		if item.User != nil {
+			record[1] = item.User.AccountsID
+			record[2] = item.User.Email.String
+			record[3] = item.User.FirstName.String
+			record[4] = item.User.LastName.String
+		}
+		if item.Room != nil {
+			record[6] = item.Room.Name
+		}
+		if err := cw.Write(record); err != nil {
+			log.Error().Err(err).Msg("write attendance csv")
+			return
 		}
-		return
-	}
-
-	if err := a.cache.dynamicConfig.Reload(a.DB); err != nil {
-		log.Error().Err(err).Msg("Reload cache")
 	}
-
-	httputil.RespondSuccess(w)
+	cw.Flush()
 }
 
 type ListParams struct {
@@ -948,6 +2994,60 @@ type RoomsResponse struct {
 	Rooms []*models.Room `json:"data"`
 }
 
+type UsersRequest struct {
+	Disabled null.Bool
+	Removed  null.Bool
+	Term     string
+}
+
+type UsersResponse struct {
+	ListResponse
+	Users []*models.User `json:"data"`
+}
+
+type BansRequest struct {
+	Users  []int64
+	Rooms  []int64
+	Active null.Bool
+}
+
+type BansResponse struct {
+	ListResponse
+	Bans []*models.UserBan `json:"data"`
+}
+
+// BanRequest bans a user until a given time or for a number of minutes.
+// An empty room_id bans the user from all rooms.
+type BanRequest struct {
+	UserID  int64      `json:"user_id"`
+	RoomID  null.Int64 `json:"room_id"`
+	Reason  string     `json:"reason"`
+	Until   time.Time  `json:"until"`
+	Minutes int        `json:"minutes"`
+}
+
+func (r *BanRequest) Validate() error {
+	if r.UserID <= 0 {
+		return fmt.Errorf("user_id is required")
+	}
+	if len(r.Reason) > 255 {
+		return fmt.Errorf("reason is longer than 255 characters")
+	}
+	if r.Minutes < 0 {
+		return fmt.Errorf("minutes must be a positive integer")
+	}
+	if r.Minutes == 0 && r.Until.IsZero() {
+		return fmt.Errorf("either until or minutes is required")
+	}
+	if r.Minutes > 0 && !r.Until.IsZero() {
+		return fmt.Errorf("until and minutes are mutually exclusive")
+	}
+	if r.Minutes == 0 && !r.Until.After(time.Now()) {
+		return fmt.Errorf("until must be in the future")
+	}
+	return nil
+}
+
 type DynamicConfigsResponse struct {
 	ListResponse
 	Items []*models.DynamicConfig `json:"data"`
@@ -999,20 +3099,346 @@ func ParseRoomsRequest(query url.Values) (*RoomsRequest, error) {
 	return req, nil
 }
 
+func ParseBansRequest(query url.Values) (*BansRequest, error) {
+	req := new(BansRequest)
+
+	var err error
+	if req.Users, err = parseIDsParam(query, "user_id"); err != nil {
+		return nil, err
+	}
+	if req.Rooms, err = parseIDsParam(query, "room_id"); err != nil {
+		return nil, err
+	}
+	if req.Active, err = parseBoolParam(query, "active"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func ParseUsersRequest(query url.Values) (*UsersRequest, error) {
+	req := &UsersRequest{
+		Term: query.Get("term"),
+	}
+
+	var err error
+	if req.Disabled, err = parseBoolParam(query, "disabled"); err != nil {
+		return nil, err
+	}
+	if req.Removed, err = parseBoolParam(query, "removed"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+type SessionsRequest struct {
+	Rooms       []int64
+	Gateways    []int64
+	AccountsIDs []string
+	Removed     null.Bool
+	Camera      null.Bool
+	Question    null.Bool
+	IP          string
+	CreatedFrom null.Time
+	CreatedTo   null.Time
+	RemovedFrom null.Time
+	RemovedTo   null.Time
+}
+
+type SessionDTO struct {
+	*models.Session
+	User    *models.User `json:"user,omitempty"`
+	Room    *models.Room `json:"room,omitempty"`
+	Gateway *GatewayDTO  `json:"gateway,omitempty"`
+}
+
+func NewSessionDTO(s *models.Session) *SessionDTO {
+	dto := &SessionDTO{Session: s}
+	if s.R != nil {
+		dto.User = s.R.User
+		dto.Room = s.R.Room
+		if s.R.Gateway != nil {
+			dto.Gateway = NewGatewayDTO(s.R.Gateway)
+		}
+	}
+	return dto
+}
+
+type SessionsResponse struct {
+	ListResponse
+	Sessions []*SessionDTO `json:"data"`
+}
+
+// GatewaySessionDTO is a janus session with its DB session, if we know it
+type GatewaySessionDTO struct {
+	ID      uint64      `json:"id"`
+	Session *SessionDTO `json:"session,omitempty"`
+}
+
+func NewGatewaySessionDTO(id uint64, s *models.Session) *GatewaySessionDTO {
+	dto := &GatewaySessionDTO{ID: id}
+	if s != nil {
+		dto.Session = NewSessionDTO(s)
+	}
+	return dto
+}
+
+type GatewayHandleDTO struct {
+	ID     uint64 `json:"id"`
+	Plugin string `json:"plugin,omitempty"`
+}
+
+type GatewaySessionHandlesResponse struct {
+	*GatewaySessionDTO
+	Handles []*GatewayHandleDTO `json:"handles"`
+}
+
+func ParseSessionsRequest(query url.Values) (*SessionsRequest, error) {
+	req := &SessionsRequest{
+		AccountsIDs: query["accounts_id"],
+	}
+
+	var err error
+	if req.Rooms, err = parseIDsParam(query, "room_id"); err != nil {
+		return nil, err
+	}
+	if req.Gateways, err = parseIDsParam(query, "gateway_id"); err != nil {
+		return nil, err
+	}
+	if req.Removed, err = parseBoolParam(query, "removed"); err != nil {
+		return nil, err
+	}
+	if req.Camera, err = parseBoolParam(query, "camera"); err != nil {
+		return nil, err
+	}
+	if req.Question, err = parseBoolParam(query, "question"); err != nil {
+		return nil, err
+	}
+
+	strVal := query.Get("ip")
+	if strVal != "" {
+		if ip := net.ParseIP(strVal); ip != nil {
+			req.IP = ip.String()
+		} else if _, ipNet, err := net.ParseCIDR(strVal); err == nil {
+			req.IP = ipNet.String()
+		} else {
+			return nil, fmt.Errorf("ip must be either an IP address or a CIDR block")
+		}
+	}
+
+	if req.CreatedFrom, err = parseTimeParam(query, "created_from"); err != nil {
+		return nil, err
+	}
+	if req.CreatedTo, err = parseTimeParam(query, "created_to"); err != nil {
+		return nil, err
+	}
+	if req.RemovedFrom, err = parseTimeParam(query, "removed_from"); err != nil {
+		return nil, err
+	}
+	if req.RemovedTo, err = parseTimeParam(query, "removed_to"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// UpdateUserRequest holds the user fields to update.
+// Omitted (or null) fields are left as is, an empty string clears a field.
+type UpdateUserRequest struct {
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Email     *string `json:"email"`
+	Username  *string `json:"username"`
+	Disabled  *bool   `json:"disabled"`
+}
+
+type KickRequest struct {
+	Comment      string `json:"comment"`
+	BlockMinutes int    `json:"block_minutes"`
+}
+
+type KickSessionResult struct {
+	Session int64  `json:"session"`
+	Error   string `json:"error"`
+}
+
+type KickResponse struct {
+	Error  string               `json:"error"`
+	Failed []*KickSessionResult `json:"failed"`
+}
+
+type SessionEventsRequest struct {
+	Types   []string
+	Sources []string
+	From    null.Time
+	To      null.Time
+}
+
+type SessionEventsResponse struct {
+	ListResponse
+	Items []*models.SessionEvent `json:"data"`
+}
+
+func ParseSessionEventsRequest(query url.Values) (*SessionEventsRequest, error) {
+	req := &SessionEventsRequest{
+		Types:   query["type"],
+		Sources: query["source"],
+	}
+
+	var err error
+	if req.From, err = parseTimeParam(query, "from"); err != nil {
+		return nil, err
+	}
+	if req.To, err = parseTimeParam(query, "to"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+const dateLayout = "2006-01-02"
+
+type AttendanceRequest struct {
+	From    time.Time
+	To      time.Time
+	Rooms   []int64
+	Regions []string
+}
+
+type AttendanceSummary struct {
+	DistinctUsers int64             `json:"distinct_users"`
+	TotalMinutes  int64             `json:"total_minutes"`
+	Rooms         []*RoomAttendance `json:"rooms"`
+}
+
+type RoomAttendance struct {
+	RoomID        int64  `json:"room_id"`
+	Name          string `json:"name"`
+	Region        string `json:"region,omitempty"`
+	DistinctUsers int64  `json:"distinct_users"`
+	TotalMinutes  int64  `json:"total_minutes"`
+}
+
+type AttendanceDTO struct {
+	*models.DailyAttendance
+	User *models.User `json:"user,omitempty"`
+	Room *models.Room `json:"room,omitempty"`
+}
+
+func NewAttendanceDTO(da *models.DailyAttendance) *AttendanceDTO {
+	dto := &AttendanceDTO{DailyAttendance: da}
+	if da.R != nil {
+		dto.User = da.R.User
+		dto.Room = da.R.Room
+	}
+	return dto
+}
+
+type AttendanceResponse struct {
+	ListResponse
+	Summary *AttendanceSummary `json:"summary"`
+	Items   []*AttendanceDTO   `json:"data"`
+}
+
+// ParseAttendanceRequest parses the report filters. Dates are inclusive and default to today (UTC).
+func ParseAttendanceRequest(query url.Values) (*AttendanceRequest, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	req := &AttendanceRequest{
+		From:    today,
+		To:      today,
+		Regions: query["region"],
+	}
+
+	var err error
+	if strVal := query.Get("from"); strVal != "" {
+		if req.From, err = time.Parse(dateLayout, strVal); err != nil {
+			return nil, fmt.Errorf("from must be YYYY-MM-DD: %w", err)
+		}
+	}
+	if strVal := query.Get("to"); strVal != "" {
+		if req.To, err = time.Parse(dateLayout, strVal); err != nil {
+			return nil, fmt.Errorf("to must be YYYY-MM-DD: %w", err)
+		}
+	}
+	if req.To.Before(req.From) {
+		return nil, fmt.Errorf("to must not be before from")
+	}
+	if req.Rooms, err = parseIDsParam(query, "room_id"); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// parseIDsParam parses an optional, possibly repeated, positive integer query parameter
+func parseIDsParam(query url.Values, key string) ([]int64, error) {
+	strVals := query[key]
+	if len(strVals) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(strVals))
+	for i, sVal := range strVals {
+		if val, err := strconv.ParseInt(sVal, 10, 64); err != nil {
+			return nil, fmt.Errorf("%s is not an integer: %w", key, err)
+		} else if val < 1 {
+			return nil, fmt.Errorf("%s must be at least 1", key)
+		} else {
+			ids[i] = val
+		}
+	}
+
+	return ids, nil
+}
+
+// parseBoolParam parses an optional `true` / `false` query parameter
+func parseBoolParam(query url.Values, key string) (null.Bool, error) {
+	switch query.Get(key) {
+	case "":
+		return null.Bool{}, nil
+	case "true":
+		return null.BoolFrom(true), nil
+	case "false":
+		return null.BoolFrom(false), nil
+	default:
+		return null.Bool{}, fmt.Errorf("%s must be either `true` or `false`", key)
+	}
+}
+
+// parseTimeParam parses an optional RFC3339 timestamp query parameter
+func parseTimeParam(query url.Values, key string) (null.Time, error) {
+	strVal := query.Get(key)
+	if strVal == "" {
+		return null.Time{}, nil
+	}
+
+	val, err := time.Parse(time.RFC3339, strVal)
+	if err != nil {
+		return null.Time{}, fmt.Errorf("%s is not a valid RFC3339 timestamp: %w", key, err)
+	}
+
+	return null.TimeFrom(val.UTC()), nil
+}
+
 type GatewayDTO struct {
-	ID          int64       `json:"id"`
-	Name        string      `json:"name"`
-	Description null.String `json:"description,omitempty"`
-	URL         string      `json:"url"`
-	Disabled    bool        `json:"disabled"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   null.Time   `json:"updated_at,omitempty"`
-	RemovedAt   null.Time   `json:"removed_at,omitempty"`
-	Type        string      `json:"type"`
+	ID          int64                 `json:"id"`
+	Name        string                `json:"name"`
+	Description null.String           `json:"description,omitempty"`
+	URL         string                `json:"url"`
+	Disabled    bool                  `json:"disabled"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   null.Time             `json:"updated_at,omitempty"`
+	RemovedAt   null.Time             `json:"removed_at,omitempty"`
+	Type        string                `json:"type"`
+	Draining    bool                  `json:"draining"`
+	Region      null.String           `json:"region,omitempty"`
+	MaxSessions null.Int              `json:"max_sessions,omitempty"`
+	Health      *domain.GatewayHealth `json:"health,omitempty"`
 }
 
 func NewGatewayDTO(g *models.Gateway) *GatewayDTO {
-	return &GatewayDTO{
+	dto := &GatewayDTO{
 		ID:          g.ID,
 		Name:        g.Name,
 		Description: g.Description,
@@ -1022,7 +3448,180 @@ func NewGatewayDTO(g *models.Gateway) *GatewayDTO {
 		UpdatedAt:   g.UpdatedAt,
 		RemovedAt:   g.RemovedAt,
 		Type:        g.Type,
+		Draining:    g.Draining,
+		Region:      g.Region,
+		MaxSessions: g.MaxSessions,
+	}
+	if health, ok := domain.GatewayHealthRegistry.Get(g); ok {
+		dto.Health = &health
+	}
+	return dto
+}
+
+// GatewayRequest holds gateway secrets in plain text.
+// Empty passwords on update keep the existing ones.
+type GatewayRequest struct {
+	Name           string      `json:"name"`
+	Description    null.String `json:"description"`
+	URL            string      `json:"url"`
+	AdminURL       string      `json:"admin_url"`
+	AdminPassword  string      `json:"admin_password"`
+	EventsPassword string      `json:"events_password"`
+	Type           string      `json:"type"`
+	Disabled       bool        `json:"disabled"`
+	Region         null.String `json:"region"`
+	MaxSessions    null.Int    `json:"max_sessions"`
+}
+
+func (r *GatewayRequest) Validate(create bool) error {
+	if len(r.Name) == 0 || len(r.Name) > 16 {
+		return fmt.Errorf("name is missing or longer than 16 characters")
+	}
+	if r.Type != common.GatewayTypeRooms && r.Type != common.GatewayTypeStreaming {
+		return fmt.Errorf("type must be either `%s` or `%s`", common.GatewayTypeRooms, common.GatewayTypeStreaming)
+	}
+	if len(r.URL) == 0 || len(r.URL) > 1024 {
+		return fmt.Errorf("url is missing or longer than 1024 characters")
+	}
+	if len(r.AdminURL) == 0 || len(r.AdminURL) > 1024 {
+		return fmt.Errorf("admin_url is missing or longer than 1024 characters")
+	}
+	if create && r.AdminPassword == "" {
+		return fmt.Errorf("admin_password is required")
+	}
+	if create && r.EventsPassword == "" {
+		return fmt.Errorf("events_password is required")
+	}
+	if len(r.Region.String) > 32 {
+		return fmt.Errorf("region is longer than 32 characters")
+	}
+	if r.MaxSessions.Valid && r.MaxSessions.Int <= 0 {
+		return fmt.Errorf("max_sessions must be positive")
+	}
+	return nil
+}
+
+// redacted returns a copy safe for logging
+func (r GatewayRequest) redacted() GatewayRequest {
+	if r.AdminPassword != "" {
+		r.AdminPassword = "***"
+	}
+	if r.EventsPassword != "" {
+		r.EventsPassword = "***"
+	}
+	return r
+}
+
+type GatewayDrainStatus struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Draining bool   `json:"draining"`
+	Sessions int    `json:"sessions"`
+	Hints    int    `json:"hints,omitempty"`
+}
+
+type RoomResponse struct {
+	*models.Room
+	Gateways []*domain.GatewayStepResult `json:"gateways,omitempty"`
+}
+
+type GatewaysSagaResponse struct {
+	Result   string                      `json:"result,omitempty"`
+	Error    string                      `json:"error,omitempty"`
+	Gateways []*domain.GatewayStepResult `json:"gateways"`
+}
+
+type RoomProfileResponse struct {
+	*models.RoomProfile
+	Gateways []*domain.GatewayStepResult `json:"gateways,omitempty"`
+}
+
+type RoomProfilesResponse struct {
+	ListResponse
+	Items   []*models.RoomProfile `json:"data"`
+	Default *models.RoomProfile   `json:"default"` // used by rooms without a profile
+}
+
+func validateRoomProfile(p *models.RoomProfile) error {
+	if len(p.Name) == 0 || len(p.Name) > 64 {
+		return fmt.Errorf("name is missing or longer than 64 characters")
+	}
+	if len(p.Description.String) > 255 {
+		return fmt.Errorf("description is longer than 255 characters")
+	}
+	if p.Publishers <= 0 {
+		return fmt.Errorf("publishers must be positive")
+	}
+	if p.Bitrate < 0 {
+		return fmt.Errorf("bitrate must not be negative")
+	}
+	if p.FirFreq < 0 {
+		return fmt.Errorf("fir_freq must not be negative")
+	}
+	if len(p.Audiocodec) == 0 || len(p.Audiocodec) > 64 {
+		return fmt.Errorf("audiocodec is missing or longer than 64 characters")
+	}
+	if len(p.Videocodec) == 0 || len(p.Videocodec) > 64 {
+		return fmt.Errorf("videocodec is missing or longer than 64 characters")
+	}
+	if len(p.H264Profile.String) > 64 {
+		return fmt.Errorf("h264_profile is longer than 64 characters")
+	}
+	return nil
+}
+
+type MountpointResponse struct {
+	*models.Mountpoint
+	Gateways []*domain.GatewayStepResult `json:"gateways,omitempty"`
+}
+
+type MountpointsResponse struct {
+	ListResponse
+	Items []*models.Mountpoint `json:"data"`
+}
+
+func validateMountpoint(m *models.Mountpoint) error {
+	if len(m.Name) == 0 || len(m.Name) > 64 {
+		return fmt.Errorf("name is missing or longer than 64 characters")
+	}
+	if len(m.Description.String) > 255 {
+		return fmt.Errorf("description is longer than 255 characters")
+	}
+	if m.GatewayUID < 0 {
+		return fmt.Errorf("gateway_uid must be a positive integer")
+	}
+	if !m.Audio && !m.Video && !m.Data {
+		return fmt.Errorf("at least one of audio, video or data is required")
+	}
+	validPort := func(port null.Int) bool {
+		return port.Valid && port.Int > 0 && port.Int <= 65535
+	}
+	validPT := func(pt null.Int) bool {
+		return pt.Valid && pt.Int >= 0 && pt.Int <= 127
+	}
+	if m.Audio {
+		if !validPort(m.AudioPort) || !validPT(m.AudioPT) {
+			return fmt.Errorf("audio requires a valid audio_port and audio_pt")
+		}
+		if len(m.AudioRtpmap.String) == 0 || len(m.AudioRtpmap.String) > 64 {
+			return fmt.Errorf("audio_rtpmap is missing or longer than 64 characters")
+		}
+	}
+	if m.Video {
+		if !validPort(m.VideoPort) || !validPT(m.VideoPT) {
+			return fmt.Errorf("video requires a valid video_port and video_pt")
+		}
+		if len(m.VideoRtpmap.String) == 0 || len(m.VideoRtpmap.String) > 64 {
+			return fmt.Errorf("video_rtpmap is missing or longer than 64 characters")
+		}
+		if len(m.VideoFMTP.String) > 255 {
+			return fmt.Errorf("video_fmtp is longer than 255 characters")
+		}
+	}
+	if m.Data && !validPort(m.DataPort) {
+		return fmt.Errorf("data requires a valid data_port")
 	}
+	return nil
 }
 
 type GatewaysResponse struct {
@@ -2,8 +2,10 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -11,14 +13,19 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/edoshor/janus-go"
+	janus_admin "github.com/edoshor/janus-go/admin"
 	janus_plugins "github.com/edoshor/janus-go/plugins"
+	"github.com/stretchr/testify/mock"
 	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
 	"github.com/Bnei-Baruch/gxydb-api/domain"
 	"github.com/Bnei-Baruch/gxydb-api/models"
 	"github.com/Bnei-Baruch/gxydb-api/pkg/stringutil"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/testutil/mocks"
 )
 
 func (s *ApiTestSuite) TestAdmin_ListGatewaysForbidden() {
@@ -78,7 +85,294 @@ func (s *ApiTestSuite) TestAdmin_ListGateways() {
 		s.Equal(gatewayData["description"], gateway.Description.String, "description")
 		s.NotContains(gatewayData, "admin_password", "admin_password")
 		s.NotContains(gatewayData, "events_password", "events_password")
+		s.NotContains(gatewayData, "health", "health")
 	}
+
+	domain.GatewayHealthRegistry.ReportSuccess(gateways[0], 15*time.Millisecond, make([]uint64, 7))
+	defer domain.GatewayHealthRegistry.Remove(gateways[0])
+	req, _ = http.NewRequest("GET", "/admin/gateways?page_no=1&page_size=1&order_by=id", nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	health := body["data"].([]interface{})[0].(map[string]interface{})["health"].(map[string]interface{})
+	s.Equal(true, health["healthy"], "healthy")
+	s.Equal(15, int(health["latency_ms"].(float64)), "latency_ms")
+	s.Equal(7, int(health["sessions"].(float64)), "sessions")
+}
+
+func (s *ApiTestSuite) TestAdmin_GatewayCRUDForbidden() {
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		req, _ := http.NewRequest(method, "/admin/gateways/1", nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code, method)
+	}
+
+	req, _ := http.NewRequest("POST", "/admin/gateways", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_CreateGatewayBadRequest() {
+	existing := s.CreateGateway()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	valid := GatewayRequest{
+		Name:           "gxy_new",
+		URL:            "ws://localhost:8188/",
+		AdminURL:       s.GatewayManager.Config.AdminURL,
+		AdminPassword:  s.GatewayManager.Config.AdminSecret,
+		EventsPassword: "events",
+		Type:           common.GatewayTypeRooms,
+	}
+
+	payloads := make([]GatewayRequest, 10)
+	for i := range payloads {
+		payloads[i] = valid
+	}
+	payloads[0].Name = ""
+	payloads[1].Name = stringutil.GenerateName(17)
+	payloads[2].Type = "unknown"
+	payloads[3].URL = ""
+	payloads[4].AdminPassword = ""
+	payloads[5].EventsPassword = ""
+	payloads[6].Name = existing.Name
+	payloads[7].AdminURL = "http://localhost:1/admin"
+	payloads[8].Region = null.StringFrom(stringutil.GenerateName(33))
+	payloads[9].MaxSessions = null.IntFrom(0)
+
+	for i, payload := range payloads {
+		b, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", "/admin/gateways", bytes.NewBuffer(b))
+		s.apiAuthP(req, []string{common.RoleRoot})
+		resp := s.request(req)
+		s.Equal(http.StatusBadRequest, resp.Code, i)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_CreateGateway() {
+	payload := GatewayRequest{
+		Name:           "gxy_new",
+		Description:    null.StringFrom("new gateway"),
+		URL:            "ws://localhost:8188/",
+		AdminURL:       s.GatewayManager.Config.AdminURL,
+		AdminPassword:  s.GatewayManager.Config.AdminSecret,
+		EventsPassword: "events",
+		Type:           common.GatewayTypeRooms,
+		Region:         null.StringFrom("eu"),
+		MaxSessions:    null.IntFrom(500),
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", "/admin/gateways", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request201json(req)
+	s.Equal(payload.Name, body["name"], "name")
+	s.Equal(payload.Description.String, body["description"], "description")
+	s.Equal(payload.Region.String, body["region"], "region")
+	s.Equal(payload.MaxSessions.Int, int(body["max_sessions"].(float64)), "max_sessions")
+	s.NotContains(body, "admin_password", "admin_password")
+	s.NotContains(body, "events_password", "events_password")
+
+	gateway, err := models.FindGateway(s.DB, int64(body["id"].(float64)))
+	s.Require().NoError(err)
+	adminPwd, err := domain.AdminPassword(gateway)
+	s.Require().NoError(err)
+	s.Equal(payload.AdminPassword, adminPwd, "admin_password")
+	s.NoError(bcrypt.CompareHashAndPassword([]byte(gateway.EventsPassword), []byte(payload.EventsPassword)), "events_password")
+
+	cached, ok := s.app.cache.gateways.ByName(payload.Name)
+	s.Require().True(ok, "cache")
+	s.Equal(gateway.ID, cached.ID, "cached gateway")
+}
+
+func (s *ApiTestSuite) TestAdmin_UpdateGateway() {
+	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	janusAdminAPI := new(mocks.AdminAPI)
+	janusAdminAPI.On("Close").Return(nil).Once()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+
+	payload := GatewayRequest{
+		Name:           "ignored",
+		Description:    null.StringFrom("updated"),
+		URL:            "ws://updated:8188/",
+		AdminURL:       gateway.AdminURL,
+		EventsPassword: "new_events",
+		Type:           common.GatewayTypeRooms,
+		Disabled:       true,
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/admin/gateways/%d", gateway.ID), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request200json(req)
+	s.Equal(gateway.Name, body["name"], "name")
+	s.Equal("updated", body["description"], "description")
+	s.Equal(true, body["disabled"], "disabled")
+
+	adminPwd := gateway.AdminPassword
+	s.Require().NoError(gateway.Reload(s.DB))
+	s.Equal("ws://updated:8188/", gateway.URL, "url")
+	s.Equal(adminPwd, gateway.AdminPassword, "admin_password kept")
+	s.NoError(bcrypt.CompareHashAndPassword([]byte(gateway.EventsPassword), []byte("new_events")), "events_password")
+
+	cached, ok := s.app.cache.gateways.ByName(gateway.Name)
+	s.Require().True(ok, "cache")
+	s.True(cached.Disabled, "cached disabled")
+	_, ok = domain.GatewayAdminAPIRegistry.Get(gateway)
+	s.False(ok, "admin API client invalidated")
+	janusAdminAPI.AssertExpectations(s.T())
+
+	// enabling with a bad admin url
+	payload.Disabled = false
+	payload.AdminURL = "http://localhost:1/admin"
+	b, _ = json.Marshal(payload)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/gateways/%d", gateway.ID), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Equal(http.StatusBadRequest, resp.Code, "unreachable")
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteGateway() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	other := s.CreateGateway()
+	session := s.CreateSession(s.CreateUser(), other, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/admin/gateways/%d", gateway.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Equal(http.StatusBadRequest, resp.Code, "default gateway of rooms")
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/gateways/%d", other.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Equal(http.StatusBadRequest, resp.Code, "active sessions")
+
+	session.RemovedAt = null.TimeFrom(time.Now().UTC())
+	_, err := session.Update(s.DB, boil.Whitelist(models.SessionColumns.RemovedAt))
+	s.Require().NoError(err)
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/gateways/%d", other.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	s.request200json(req)
+
+	s.Require().NoError(other.Reload(s.DB))
+	s.True(other.RemovedAt.Valid, "removed_at")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/gateways/%d", other.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Equal(http.StatusNotFound, resp.Code, "removed gateway")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/gateways/%s/sessions", other.Name), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Equal(http.StatusNotFound, resp.Code, "browse removed gateway")
+
+	req, _ = http.NewRequest("POST", "/event", bytes.NewBuffer([]byte("{}")))
+	req.SetBasicAuth(other.Name, other.Name)
+	resp = s.request(req)
+	s.Equal(http.StatusUnauthorized, resp.Code, "removed gateway events")
+}
+
+func (s *ApiTestSuite) TestAdmin_DrainGatewayForbidden() {
+	gateway := s.CreateGateway()
+	for _, method := range []string{"POST", "DELETE"} {
+		req, _ := http.NewRequest(method, fmt.Sprintf("/admin/gateways/%d/drain", gateway.ID), nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code, method)
+	}
+
+	req, _ := http.NewRequest("GET", "/admin/gateways/draining", nil)
+	s.apiAuthP(req, []string{common.RoleUser})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_DrainGateway() {
+	streaming := s.CreateGatewayP(common.GatewayTypeStreaming, "admin_url", "janusoverlord")
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/gateways/%d/drain", streaming.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "streaming gateway")
+
+	gateway := s.CreateGateway()
+	other := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	users := []*models.User{s.CreateUser(), s.CreateUser()}
+	sessions := make([]*models.Session, len(users))
+	for i, user := range users {
+		sessions[i] = s.CreateSession(user, gateway, room)
+	}
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	ch, _, _, _ := s.app.roomsStream.Subscribe("")
+	defer s.app.roomsStream.Unsubscribe(ch)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/admin/gateways/%d/drain", gateway.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request200json(req)
+	s.Equal(true, body["draining"], "draining")
+	s.Equal(2, int(body["sessions"].(float64)), "sessions")
+	s.Equal(2, int(body["hints"].(float64)), "hints")
+
+	hinted := make([]string, 0)
+	for range users {
+		select {
+		case event := <-ch:
+			s.Equal(RoomEventMigrate, event.Type, "event.Type")
+			s.Equal(room.GatewayUID, event.Room, "event.Room")
+			s.Equal(other.Name, event.Gateway, "event.Gateway")
+			hinted = append(hinted, event.User)
+		case <-time.After(time.Second):
+			s.FailNow("timeout waiting for migrate hint")
+		}
+	}
+	s.ElementsMatch([]string{users[0].AccountsID, users[1].AccountsID}, hinted, "hinted users")
+
+	req, _ = http.NewRequest("GET", "/v2/config", nil)
+	s.apiAuth(req)
+	body = s.request200json(req)
+	roomsGateways := body["gateways"].(map[string]interface{})[common.GatewayTypeRooms].(map[string]interface{})
+	s.NotContains(roomsGateways, gateway.Name, "config")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/v2/assign?room=%d", room.GatewayUID), nil)
+	s.apiAuth(req)
+	body = s.request200json(req)
+	s.Equal(other.Name, body["gateway"].(map[string]interface{})["name"], "assign")
+
+	draining := func() []interface{} {
+		req, _ := http.NewRequest("GET", "/admin/gateways/draining", nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusOK, resp.Code)
+		var data []interface{}
+		s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &data))
+		return data
+	}
+
+	data := draining()
+	s.Require().Len(data, 1, "draining gateways")
+	status := data[0].(map[string]interface{})
+	s.Equal(gateway.Name, status["name"], "name")
+	s.Equal(2, int(status["sessions"].(float64)), "sessions")
+
+	for _, session := range sessions {
+		session.RemovedAt = null.TimeFrom(time.Now().UTC())
+		_, err := session.Update(s.DB, boil.Whitelist(models.SessionColumns.RemovedAt))
+		s.Require().NoError(err)
+	}
+	data = draining()
+	s.Require().Len(data, 1, "draining gateways")
+	s.Equal(0, int(data[0].(map[string]interface{})["sessions"].(float64)), "sessions after users left")
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/gateways/%d/drain", gateway.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.Equal(false, body["draining"], "undrained")
+	s.Empty(draining(), "no draining gateways")
 }
 
 func (s *ApiTestSuite) TestAdmin_GatewaysHandleInfoForbidden() {
@@ -136,6 +430,93 @@ func (s *ApiTestSuite) TestAdmin_GatewaysHandleInfo() {
 	s.NotNil(body["info"], "info")
 }
 
+func (s *ApiTestSuite) TestAdmin_GatewaySessionsForbidden() {
+	for _, path := range []string{"/admin/gateways/1/info", "/admin/gateways/1/sessions", "/admin/gateways/1/sessions/1/handles"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		resp := s.request(req)
+		s.Require().Equal(http.StatusUnauthorized, resp.Code, path)
+
+		req, _ = http.NewRequest("GET", path, nil)
+		s.apiAuth(req)
+		resp = s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code, path)
+
+		req, _ = http.NewRequest("GET", path, nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp = s.request(req)
+		s.Require().Equal(http.StatusNotFound, resp.Code, path)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_GatewaySessions() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	session.GatewayHandleTextroom = null.Int64From(rand.Int63n(math.MaxInt32))
+	_, err := session.Update(s.DB, boil.Whitelist(models.SessionColumns.GatewayHandleTextroom))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	gSessionID := uint64(session.GatewaySession.Int64)
+	unknownSession := uint64(math.MaxInt32 + 1)
+	unknownHandle := uint64(math.MaxInt32 + 1)
+	janusAdminAPI.On("ListSessions").Return(&janus_admin.ListSessionsResponse{
+		Sessions: []uint64{gSessionID, unknownSession},
+	}, nil)
+	janusAdminAPI.On("ListHandles", gSessionID).Return(&janus_admin.ListHandlesResponse{
+		Handles: []uint64{uint64(session.GatewayHandle.Int64), uint64(session.GatewayHandleTextroom.Int64), unknownHandle},
+	}, nil)
+	janusAdminAPI.On("ListHandles", uint64(1)).Return(nil, &janus_admin.ErrorAMResponse{
+		Err: janus.ErrorData{Code: 458, Reason: "No such session"},
+	})
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/gateways/%s/sessions", gateway.Name), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusOK, resp.Code)
+	var sessions []map[string]interface{}
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &sessions))
+	s.Require().Len(sessions, 2, "sessions")
+	s.EqualValues(gSessionID, sessions[0]["id"], "id")
+	dbSession := sessions[0]["session"].(map[string]interface{})
+	s.EqualValues(session.ID, dbSession["id"], "db session")
+	s.Equal(user.AccountsID, dbSession["user"].(map[string]interface{})["accounts_id"], "user")
+	s.Equal(room.Name, dbSession["room"].(map[string]interface{})["name"], "room")
+	s.EqualValues(unknownSession, sessions[1]["id"], "unknown id")
+	s.Nil(sessions[1]["session"], "unknown session")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/gateways/%s/sessions/%d/handles", gateway.Name, gSessionID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.EqualValues(gSessionID, body["id"], "id")
+	s.EqualValues(session.ID, body["session"].(map[string]interface{})["id"], "db session")
+	handles := body["handles"].([]interface{})
+	s.Require().Len(handles, 3, "handles")
+	s.Equal("janus.plugin.videoroom", handles[0].(map[string]interface{})["plugin"], "videoroom handle")
+	s.Equal("janus.plugin.textroom", handles[1].(map[string]interface{})["plugin"], "textroom handle")
+	s.EqualValues(unknownHandle, handles[2].(map[string]interface{})["id"], "unknown handle")
+	s.Nil(handles[2].(map[string]interface{})["plugin"], "unknown handle plugin")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/gateways/%s/sessions/1/handles", gateway.Name), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code, "no such session")
+}
+
+func (s *ApiTestSuite) TestAdmin_GatewayInfo() {
+	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/gateways/%s/info", gateway.Name), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.Equal("server_info", body["janus"], "janus")
+	s.NotNil(body["plugins"], "plugins")
+}
+
 func (s *ApiTestSuite) TestAdmin_ListRoomsForbidden() {
 	req, _ := http.NewRequest("GET", "/admin/rooms", nil)
 	resp := s.request(req)
@@ -405,6 +786,9 @@ func (s *ApiTestSuite) TestAdmin_CreateRoom() {
 	gRoom := s.findRoomInGateway(gateway, int(body["gateway_uid"].(float64)))
 	s.Require().NotNil(gRoom, "gateway room")
 	s.Equal(gRoom.Description, payload.Name, "gateway room description")
+	tRoom := s.findTextroomInGateway(gateway, int(body["gateway_uid"].(float64)))
+	s.Require().NotNil(tRoom, "gateway textroom")
+	s.Equal(tRoom.Description, payload.Name, "gateway textroom description")
 }
 
 func (s *ApiTestSuite) TestAdmin_UpdateRoomForbidden() {
@@ -526,118 +910,1406 @@ func (s *ApiTestSuite) TestAdmin_UpdateRoom() {
 	gRoom := s.findRoomInGateway(gateway, int(body["gateway_uid"].(float64)))
 	s.Require().NotNil(gRoom, "gateway room")
 	s.Equal(gRoom.Description, payload.Name, "gateway room description")
+	tRoom := s.findTextroomInGateway(gateway, int(body["gateway_uid"].(float64)))
+	s.Require().NotNil(tRoom, "gateway textroom")
+	s.Equal(tRoom.Description, payload.Name, "gateway textroom description")
 }
 
-func (s *ApiTestSuite) TestAdmin_DeleteRoomForbidden() {
-	req, _ := http.NewRequest("DELETE", "/admin/rooms/1", nil)
+func (s *ApiTestSuite) TestAdmin_RoomProfilesForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/room_profiles", nil)
 	resp := s.request(req)
 	s.Require().Equal(http.StatusUnauthorized, resp.Code)
 
-	req, _ = http.NewRequest("DELETE", "/admin/rooms/1", nil)
-	s.apiAuth(req)
-	resp = s.request(req)
-	s.Require().Equal(http.StatusForbidden, resp.Code)
+	for _, method := range []string{"POST", "GET"} {
+		req, _ = http.NewRequest(method, "/admin/room_profiles", nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp = s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code)
+	}
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		req, _ = http.NewRequest(method, "/admin/room_profiles/1", nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp = s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code)
+	}
 }
 
-func (s *ApiTestSuite) TestAdmin_DeleteRoomNotFound() {
-	req, _ := http.NewRequest("DELETE", "/admin/rooms/abc", nil)
+func (s *ApiTestSuite) TestAdmin_RoomProfiles() {
+	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("GET", "/admin/room_profiles", nil)
 	s.apiAuthP(req, []string{common.RoleRoot})
-	resp := s.request(req)
-	s.Require().Equal(http.StatusNotFound, resp.Code)
+	body := s.request200json(req)
+	s.EqualValues(0, body["total"], "total")
+	s.Equal(float64(domain.DefaultRoomProfile.Publishers), body["default"].(map[string]interface{})["publishers"], "default publishers")
+
+	// create
+	profile := *domain.DefaultRoomProfile
+	profile.Name = fmt.Sprintf("conference_%s", stringutil.GenerateName(6))
+	profile.Publishers = 200
+	profile.Bitrate = 128000
+	for _, bad := range []func(p models.RoomProfile) models.RoomProfile{
+		func(p models.RoomProfile) models.RoomProfile { p.Name = ""; return p },
+		func(p models.RoomProfile) models.RoomProfile { p.Publishers = 0; return p },
+		func(p models.RoomProfile) models.RoomProfile { p.Bitrate = -1; return p },
+		func(p models.RoomProfile) models.RoomProfile { p.Videocodec = ""; return p },
+	} {
+		b, _ := json.Marshal(bad(profile))
+		req, _ = http.NewRequest("POST", "/admin/room_profiles", bytes.NewBuffer(b))
+		s.apiAuthP(req, []string{common.RoleRoot})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusBadRequest, resp.Code)
+	}
 
-	req, _ = http.NewRequest("DELETE", "/admin/rooms/1", nil)
+	b, _ := json.Marshal(profile)
+	req, _ = http.NewRequest("POST", "/admin/room_profiles", bytes.NewBuffer(b))
 	s.apiAuthP(req, []string{common.RoleRoot})
-	resp = s.request(req)
-	s.Require().Equal(http.StatusNotFound, resp.Code)
-}
+	body = s.request201json(req)
+	s.Equal(profile.Name, body["name"], "name")
+	s.EqualValues(profile.Publishers, body["publishers"], "publishers")
+	profileID := int64(body["id"].(float64))
 
-func (s *ApiTestSuite) TestAdmin_DeleteRoom() {
-	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
-	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	req, _ = http.NewRequest("POST", "/admin/room_profiles", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "existing name")
 
-	payload := models.Room{
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/room_profiles/%d", profileID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.EqualValues(profile.Bitrate, body["bitrate"], "bitrate")
+
+	// rooms with a profile
+	room := models.Room{
 		Name:             fmt.Sprintf("room_%s", stringutil.GenerateName(10)),
-		GatewayUID:       strconv.Itoa(rand.Intn(math.MaxInt16)),
+		GatewayUID:       rand.Intn(math.MaxInt32),
 		DefaultGatewayID: gateway.ID,
+		RoomProfileID:    null.Int64From(-1),
 	}
-	b, _ := json.Marshal(payload)
-	req, _ := http.NewRequest("POST", "/admin/rooms", bytes.NewBuffer(b))
+	b, _ = json.Marshal(room)
+	req, _ = http.NewRequest("POST", "/admin/rooms", bytes.NewBuffer(b))
 	s.apiAuthP(req, []string{common.RoleRoot})
-	body := s.request201json(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "non existing profile")
 
-	id := int64(body["id"].(float64))
-	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/rooms/%d", id), nil)
+	room.RoomProfileID = null.Int64From(profileID)
+	b, _ = json.Marshal(room)
+	req, _ = http.NewRequest("POST", "/admin/rooms", bytes.NewBuffer(b))
 	s.apiAuthP(req, []string{common.RoleRoot})
-	s.request200json(req)
+	body = s.request201json(req)
+	s.EqualValues(profileID, body["room_profile_id"], "room_profile_id")
+	roomID := int64(body["id"].(float64))
+	gatewayUID := int(body["gateway_uid"].(float64))
 
-	// verify room removed_at is set in DB
-	room, err := models.FindRoom(s.DB, id)
-	s.Require().NoError(err, "models.FindRoom")
-	s.True(room.RemovedAt.Valid, "remove_at")
+	gRoom := s.findRoomInGateway(gateway, gatewayUID)
+	s.Require().NotNil(gRoom, "gateway room")
+	s.Equal(profile.Publishers, gRoom.MaxPublishers, "gateway room publishers")
+	s.Equal(profile.Bitrate, gRoom.Bitrate, "gateway room bitrate")
 
-	// verify room does not exist on gateway
-	s.Nil(s.findRoomInGateway(gateway, int(body["gateway_uid"].(float64))))
-}
+	// update profile edits its rooms on gateways
+	profile.Publishers = 300
+	b, _ = json.Marshal(profile)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/room_profiles/%d", profileID), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.EqualValues(profile.Publishers, body["publishers"], "publishers")
+	s.Len(body["gateways"], 1, "gateways results")
+	gRoom = s.findRoomInGateway(gateway, gatewayUID)
+	s.Require().NotNil(gRoom, "gateway room")
+	s.Equal(profile.Publishers, gRoom.MaxPublishers, "gateway room publishers after profile update")
 
-func (s *ApiTestSuite) TestAdmin_DeleteRoomsStatistics() {
-	gateway := s.CreateGateway()
-	rooms := make([]*models.Room, 5)
-	for i := range rooms {
-		rooms[i] = s.CreateRoom(gateway)
-		roomStatistic := models.RoomStatistic{
-			RoomID: rooms[i].ID,
-			OnAir:  i + 1,
-		}
-		err := roomStatistic.Insert(s.DB, boil.Infer())
-		s.Require().NoError(err, "create RoomStatistic")
-	}
-	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/room_profiles/%d", profileID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "profile in use")
 
-	req, _ := http.NewRequest("DELETE", "/admin/rooms_statistics", nil)
+	// back to default profile
+	room.RoomProfileID = null.Int64{}
+	b, _ = json.Marshal(room)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/rooms/%d", roomID), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.Nil(body["room_profile_id"], "room_profile_id")
+	gRoom = s.findRoomInGateway(gateway, gatewayUID)
+	s.Require().NotNil(gRoom, "gateway room")
+	s.Equal(domain.DefaultRoomProfile.Publishers, gRoom.MaxPublishers, "gateway room publishers with default profile")
+
+	// delete
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/room_profiles/%d", profileID), nil)
 	s.apiAuthP(req, []string{common.RoleRoot})
 	s.request200json(req)
 
-	count, err := models.RoomStatistics().Count(s.DB)
-	s.Require().NoError(err)
-	s.EqualValues(0, count)
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/room_profiles/%d", profileID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
 }
 
-func (s *ApiTestSuite) TestAdmin_ListDynamicConfigsForbidden() {
-	req, _ := http.NewRequest("GET", "/admin/dynamic_config", nil)
+func (s *ApiTestSuite) TestAdmin_MountpointsForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/mountpoints", nil)
 	resp := s.request(req)
 	s.Require().Equal(http.StatusUnauthorized, resp.Code)
 
-	req, _ = http.NewRequest("GET", "/admin/dynamic_config", nil)
-	s.apiAuth(req)
-	resp = s.request(req)
-	s.Require().Equal(http.StatusForbidden, resp.Code)
+	for _, method := range []string{"POST", "GET"} {
+		req, _ = http.NewRequest(method, "/admin/mountpoints", nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp = s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code)
+	}
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		req, _ = http.NewRequest(method, "/admin/mountpoints/1", nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp = s.request(req)
+		s.Require().Equal(http.StatusForbidden, resp.Code)
+	}
 }
 
-func (s *ApiTestSuite) TestAdmin_ListDynamicConfigsBadRequest() {
-	args := [...]string{
-		"page_no=0",
-		"page_no=-1",
-		"page_no=abc",
-		"page_size=0",
-		"page_size=-1",
-		"page_size=abc",
+func (s *ApiTestSuite) TestAdmin_Mountpoints() {
+	gateway := s.CreateGatewayP(common.GatewayTypeStreaming, "http://streaming/admin", "secret")
+	janusAdminAPI := new(mocks.AdminAPI)
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	action := func(name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.PluginName() == domain.StreamingPlugin && r.ActionName() == name
+		})
 	}
-	for i, query := range args {
-		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/dynamic_config?%s", query), nil)
+
+	payload := models.Mountpoint{
+		Name:        fmt.Sprintf("mountpoint_%s", stringutil.GenerateName(6)),
+		Description: null.StringFrom("main hall"),
+		Audio:       true,
+		AudioPort:   null.IntFrom(5102),
+		AudioPT:     null.IntFrom(111),
+		AudioRtpmap: null.StringFrom("opus/48000/2"),
+	}
+
+	// bad requests
+	for _, bad := range []func(m models.Mountpoint) models.Mountpoint{
+		func(m models.Mountpoint) models.Mountpoint { m.Name = ""; return m },
+		func(m models.Mountpoint) models.Mountpoint { m.Audio = false; return m },
+		func(m models.Mountpoint) models.Mountpoint { m.AudioPort = null.IntFrom(70000); return m },
+		func(m models.Mountpoint) models.Mountpoint { m.AudioRtpmap = null.String{}; return m },
+		func(m models.Mountpoint) models.Mountpoint { m.Video = true; return m },
+	} {
+		b, _ := json.Marshal(bad(payload))
+		req, _ := http.NewRequest("POST", "/admin/mountpoints", bytes.NewBuffer(b))
 		s.apiAuthP(req, []string{common.RoleRoot})
 		resp := s.request(req)
-		s.Require().Equal(http.StatusBadRequest, resp.Code, i)
+		s.Require().Equal(http.StatusBadRequest, resp.Code)
 	}
-}
 
-func (s *ApiTestSuite) TestAdmin_ListDynamicConfigs() {
-	req, _ := http.NewRequest("GET", "/admin/dynamic_config", nil)
+	// create
+	janusAdminAPI.On("MessagePlugin", action("create")).Return(&domain.StreamingCreateResponse{}, nil).Once()
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", "/admin/mountpoints", bytes.NewBuffer(b))
 	s.apiAuthP(req, []string{common.RoleRoot})
-	body := s.request200json(req)
-	s.Equal(0, int(body["total"].(float64)), "total")
-	s.Equal(0, len(body["data"].([]interface{})), "len(data)")
+	body := s.request201json(req)
+	s.Equal(payload.Name, body["name"], "name")
+	s.NotZero(body["gateway_uid"], "gateway_uid")
+	s.Len(body["gateways"], 1, "gateways results")
+	janusAdminAPI.AssertExpectations(s.T())
+	id := int64(body["id"].(float64))
+	payload.GatewayUID = int(body["gateway_uid"].(float64))
 
-	kvs := make([]*models.DynamicConfig, 10)
+	// in config
+	req, _ = http.NewRequest("GET", "/v2/config", nil)
+	s.apiAuth(req)
+	body = s.request200json(req)
+	mountpoints := body["mountpoints"].([]interface{})
+	s.Require().Len(mountpoints, 1, "config mountpoints")
+	s.EqualValues(payload.GatewayUID, mountpoints[0].(map[string]interface{})["id"], "config mountpoint id")
+	s.Equal(payload.Name, mountpoints[0].(map[string]interface{})["name"], "config mountpoint name")
+
+	// description change is an edit
+	janusAdminAPI.On("MessagePlugin", mock.MatchedBy(func(r *domain.StreamingEditRequest) bool {
+		return r.Description == "main hall edit"
+	})).Return(&domain.StreamingEditResponse{}, nil).Once()
+	payload.Name = fmt.Sprintf("%s_edit", payload.Name)
+	payload.Description = null.StringFrom("main hall edit")
+	b, _ = json.Marshal(payload)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/mountpoints/%d", id), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.Equal(payload.Name, body["name"], "name")
+	janusAdminAPI.AssertExpectations(s.T())
+
+	// media changes recreate, which must be confirmed
+	payload.AudioPort = null.IntFrom(5104)
+	b, _ = json.Marshal(payload)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/mountpoints/%d", id), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusConflict, resp.Code, "recreate not confirmed")
+
+	janusAdminAPI.On("MessagePlugin", action("destroy")).Return(&domain.StreamingDestroyResponse{}, nil).Once()
+	janusAdminAPI.On("MessagePlugin", action("create")).Return(&domain.StreamingCreateResponse{}, nil).Once()
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/mountpoints/%d?recreate=true", id), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.EqualValues(payload.AudioPort.Int, body["audio_port"], "audio_port")
+	janusAdminAPI.AssertExpectations(s.T())
+
+	// disabled mountpoints are out of config and destroyed on gateways
+	janusAdminAPI.On("MessagePlugin", action("destroy")).Return(&domain.StreamingDestroyResponse{}, nil).Once()
+	payload.Disabled = true
+	b, _ = json.Marshal(payload)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/mountpoints/%d", id), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body = s.request200json(req)
+	s.Len(body["gateways"], 1, "gateways results")
+	janusAdminAPI.AssertExpectations(s.T())
+	req, _ = http.NewRequest("GET", "/v2/config", nil)
+	s.apiAuth(req)
+	body = s.request200json(req)
+	s.Empty(body["mountpoints"], "config mountpoints")
+
+	// delete, the disabled mountpoint is not on gateways
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/mountpoints/%d", id), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	s.request200json(req)
+	janusAdminAPI.AssertExpectations(s.T())
+	janusAdminAPI.AssertNumberOfCalls(s.T(), "MessagePlugin", 5)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/mountpoints/%d", id), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_CreateMountpointGatewayFailure() {
+	gateway := s.CreateGatewayP(common.GatewayTypeStreaming, "http://streaming/admin", "secret")
+	janusAdminAPI := new(mocks.AdminAPI)
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	failing := s.CreateGatewayP(common.GatewayTypeStreaming, "http://failing/admin", "secret")
+	failingAPI := new(mocks.AdminAPI)
+	failingAPI.On("MessagePlugin", mock.Anything).
+		Return(&domain.StreamingErrorResponse{PluginError: janus_plugins.PluginError{Code: 456, Reason: "Port already in use"}}, nil)
+	domain.GatewayAdminAPIRegistry.Set(failing, failingAPI)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	action := func(name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.ActionName() == name
+		})
+	}
+	janusAdminAPI.On("MessagePlugin", action("create")).Return(&domain.StreamingCreateResponse{}, nil).Once()
+	janusAdminAPI.On("MessagePlugin", action("destroy")).Return(&domain.StreamingDestroyResponse{}, nil).Once()
+
+	payload := models.Mountpoint{
+		Name:     fmt.Sprintf("mountpoint_%s", stringutil.GenerateName(6)),
+		Data:     true,
+		DataPort: null.IntFrom(5106),
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", "/admin/mountpoints", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadGateway, resp.Code)
+	janusAdminAPI.AssertExpectations(s.T())
+
+	var body GatewaysSagaResponse
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &body))
+	statuses := make(map[string]string)
+	for _, result := range body.Gateways {
+		statuses[result.Gateway] = result.Status
+	}
+	s.Equal(domain.GatewayStepCompensated, statuses[gateway.Name], "compensated gateway")
+	s.Equal(domain.GatewayStepFailed, statuses[failing.Name], "failing gateway")
+
+	exists, err := models.Mountpoints(models.MountpointWhere.Name.EQ(payload.Name)).Exists(s.DB)
+	s.Require().NoError(err)
+	s.False(exists, "mountpoint in DB")
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteRoomForbidden() {
+	req, _ := http.NewRequest("DELETE", "/admin/rooms/1", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("DELETE", "/admin/rooms/1", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteRoomNotFound() {
+	req, _ := http.NewRequest("DELETE", "/admin/rooms/abc", nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest("DELETE", "/admin/rooms/1", nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteRoom() {
+	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	payload := models.Room{
+		Name:             fmt.Sprintf("room_%s", stringutil.GenerateName(10)),
+		GatewayUID:       strconv.Itoa(rand.Intn(math.MaxInt16)),
+		DefaultGatewayID: gateway.ID,
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", "/admin/rooms", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request201json(req)
+
+	id := int64(body["id"].(float64))
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/rooms/%d", id), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	s.request200json(req)
+
+	// verify room removed_at is set in DB
+	room, err := models.FindRoom(s.DB, id)
+	s.Require().NoError(err, "models.FindRoom")
+	s.True(room.RemovedAt.Valid, "remove_at")
+
+	// verify room does not exist on gateway
+	s.Nil(s.findRoomInGateway(gateway, int(body["gateway_uid"].(float64))))
+	s.Nil(s.findTextroomInGateway(gateway, int(body["gateway_uid"].(float64))))
+}
+
+func (s *ApiTestSuite) TestAdmin_CreateRoomGatewayFailure() {
+	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
+	failing := s.CreateGateway()
+	janusAdminAPI := new(mocks.AdminAPI)
+	janusAdminAPI.On("MessagePlugin", mock.Anything).Return(nil, errors.New("gateway is down"))
+	domain.GatewayAdminAPIRegistry.Set(failing, janusAdminAPI)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	payload := models.Room{
+		Name:             fmt.Sprintf("room_%s", stringutil.GenerateName(10)),
+		GatewayUID:       rand.Intn(math.MaxInt32),
+		DefaultGatewayID: gateway.ID,
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", "/admin/rooms", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadGateway, resp.Code)
+
+	var body GatewaysSagaResponse
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &body))
+	statuses := make(map[string]string)
+	for _, result := range body.Gateways {
+		statuses[result.Gateway] = result.Status
+	}
+	s.Equal(domain.GatewayStepCompensated, statuses[gateway.Name], "compensated gateway")
+	s.Equal(domain.GatewayStepFailed, statuses[failing.Name], "failing gateway")
+
+	// rolled back in DB and on gateway
+	exists, err := models.Rooms(models.RoomWhere.Name.EQ(payload.Name)).Exists(s.DB)
+	s.Require().NoError(err)
+	s.False(exists, "room in DB")
+	s.Nil(s.findRoomInGateway(gateway, payload.GatewayUID), "room on gateway")
+	s.Nil(s.findTextroomInGateway(gateway, payload.GatewayUID), "textroom on gateway")
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteRoomGatewayFailure() {
+	gateway := s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	payload := models.Room{
+		Name:             fmt.Sprintf("room_%s", stringutil.GenerateName(10)),
+		GatewayUID:       rand.Intn(math.MaxInt32),
+		DefaultGatewayID: gateway.ID,
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("POST", "/admin/rooms", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request201json(req)
+	s.Len(body["gateways"], 1, "gateways results")
+	id := int64(body["id"].(float64))
+
+	failing := s.CreateGateway()
+	janusAdminAPI := new(mocks.AdminAPI)
+	janusAdminAPI.On("MessagePlugin", mock.Anything).Return(nil, errors.New("gateway is down"))
+	domain.GatewayAdminAPIRegistry.Set(failing, janusAdminAPI)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/rooms/%d", id), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadGateway, resp.Code)
+
+	// room is still there, recreated on the gateway it was destroyed on
+	room, err := models.FindRoom(s.DB, id)
+	s.Require().NoError(err)
+	s.False(room.RemovedAt.Valid, "removed_at")
+	gRoom := s.findRoomInGateway(gateway, payload.GatewayUID)
+	s.Require().NotNil(gRoom, "room on gateway")
+	s.Equal(payload.Name, gRoom.Description, "gateway room description")
+	s.NotNil(s.findTextroomInGateway(gateway, payload.GatewayUID), "textroom on gateway")
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteRoomsStatistics() {
+	gateway := s.CreateGateway()
+	rooms := make([]*models.Room, 5)
+	for i := range rooms {
+		rooms[i] = s.CreateRoom(gateway)
+		roomStatistic := models.RoomStatistic{
+			RoomID: rooms[i].ID,
+			OnAir:  i + 1,
+		}
+		err := roomStatistic.Insert(s.DB, boil.Infer())
+		s.Require().NoError(err, "create RoomStatistic")
+	}
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("DELETE", "/admin/rooms_statistics", nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	s.request200json(req)
+
+	count, err := models.RoomStatistics().Count(s.DB)
+	s.Require().NoError(err)
+	s.EqualValues(0, count)
+}
+
+func (s *ApiTestSuite) TestAdmin_ReconcileForbidden() {
+	req, _ := http.NewRequest("POST", "/admin/reconcile", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("POST", "/admin/reconcile", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/reconcile", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_Reconcile() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	sessions := make([]*models.Session, 3)
+	for i := range sessions {
+		sessions[i] = s.CreateSession(s.CreateUser(), gateway, room)
+	}
+	_, err := models.Sessions().UpdateAll(s.DB, models.M{"created_at": time.Now().UTC().Add(-time.Hour)})
+	s.Require().NoError(err)
+	young := s.CreateSession(s.CreateUser(), gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	// session 0 is alive, session 1 is gone, session 2 lost its handle.
+	// young is not on the gateway yet.
+	unknownSession := uint64(math.MaxInt32 + 1)
+	janusAdminAPI.On("ListSessions").Return(&janus_admin.ListSessionsResponse{
+		Sessions: []uint64{
+			uint64(sessions[0].GatewaySession.Int64),
+			uint64(sessions[2].GatewaySession.Int64),
+			unknownSession,
+		},
+	}, nil)
+	janusAdminAPI.On("ListHandles", uint64(sessions[0].GatewaySession.Int64)).Return(&janus_admin.ListHandlesResponse{
+		Handles: []uint64{uint64(sessions[0].GatewayHandle.Int64)},
+	}, nil)
+	janusAdminAPI.On("ListHandles", uint64(sessions[2].GatewaySession.Int64)).Return(&janus_admin.ListHandlesResponse{
+		Handles: []uint64{},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/admin/reconcile", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code, "no report yet")
+
+	req, _ = http.NewRequest("POST", "/admin/reconcile", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+
+	gReport := body["gateways"].(map[string]interface{})[gateway.Name].(map[string]interface{})
+	s.Empty(gReport["error"], "error")
+	s.EqualValues(3, gReport["db_sessions"], "db_sessions")
+	s.EqualValues(3, gReport["gateway_sessions"], "gateway_sessions")
+	s.ElementsMatch([]interface{}{float64(sessions[1].ID), float64(sessions[2].ID)}, gReport["closed"], "closed")
+	s.ElementsMatch([]interface{}{float64(unknownSession)}, gReport["unknown"], "unknown")
+
+	for i, session := range sessions {
+		s.Require().NoError(session.Reload(s.DB))
+		s.Equal(i != 0, session.RemovedAt.Valid, "removed_at %d", i)
+	}
+	s.Require().NoError(young.Reload(s.DB))
+	s.False(young.RemovedAt.Valid, "young session")
+
+	events, err := models.SessionEvents(models.SessionEventWhere.SessionID.EQ(sessions[2].ID)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "session events")
+	s.Equal(common.SessionEventSourceReconciler, events[0].Source, "event source")
+
+	req, _ = http.NewRequest("GET", "/admin/reconcile", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.NotNil(body["gateways"], "last report")
+
+	// a fresh health probe is used instead of asking the gateway
+	domain.GatewayHealthRegistry.ReportSuccess(gateway, time.Millisecond, []uint64{})
+	defer domain.GatewayHealthRegistry.Remove(gateway)
+	req, _ = http.NewRequest("POST", "/admin/reconcile", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	gReport = body["gateways"].(map[string]interface{})[gateway.Name].(map[string]interface{})
+	s.ElementsMatch([]interface{}{float64(sessions[0].ID)}, gReport["closed"], "closed by probe")
+	janusAdminAPI.AssertNumberOfCalls(s.T(), "ListSessions", 1)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessionsForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/sessions", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/sessions", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessionsBadRequest() {
+	args := [...]string{
+		"page_no=0",
+		"page_no=-1",
+		"page_no=abc",
+		"page_size=0",
+		"page_size=-1",
+		"page_size=abc",
+		"room_id=abc",
+		"room_id=0",
+		"gateway_id=abc",
+		"gateway_id=-1",
+		"removed=abc",
+		"camera=1",
+		"question=yes",
+		"ip=abc",
+		"ip=10.0.0.1/99",
+		"created_from=abc",
+		"created_to=2020-01-01",
+		"removed_from=abc",
+		"removed_to=abc",
+	}
+	for i, query := range args {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/sessions?%s", query), nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusBadRequest, resp.Code, i)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessions() {
+	req, _ := http.NewRequest("GET", "/admin/sessions", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.Equal(0, int(body["total"].(float64)), "total")
+	s.Equal(0, len(body["data"].([]interface{})), "len(data)")
+
+	gateways := []*models.Gateway{s.CreateGateway(), s.CreateGateway()}
+	rooms := []*models.Room{s.CreateRoom(gateways[0]), s.CreateRoom(gateways[1])}
+	users := make([]*models.User, 6)
+	sessions := make([]*models.Session, len(users))
+	for i := range users {
+		users[i] = s.CreateUser()
+		sessions[i] = s.CreateSession(users[i], gateways[i%2], rooms[i%2])
+	}
+
+	// session 0 is removed, session 1 asks a question, session 2 has no camera and a different ip
+	sessions[0].RemovedAt = null.TimeFrom(time.Now().UTC())
+	_, err := sessions[0].Update(s.DB, boil.Infer())
+	s.Require().NoError(err)
+	sessions[1].Question = true
+	_, err = sessions[1].Update(s.DB, boil.Infer())
+	s.Require().NoError(err)
+	sessions[2].Camera = false
+	sessions[2].IPAddress = null.StringFrom("10.0.0.8")
+	_, err = sessions[2].Update(s.DB, boil.Infer())
+	s.Require().NoError(err)
+
+	body = s.request200json(req)
+	s.Equal(len(sessions), int(body["total"].(float64)), "total")
+	data := body["data"].([]interface{})
+	s.Require().Equal(len(sessions), len(data), "len(data)")
+	for _, x := range data {
+		sessionData := x.(map[string]interface{})
+		s.NotNil(sessionData["user"], "user")
+		s.NotNil(sessionData["room"], "room")
+		gatewayData := sessionData["gateway"].(map[string]interface{})
+		s.Nil(gatewayData["admin_password"], "gateway admin_password")
+		s.Nil(gatewayData["events_password"], "gateway events_password")
+	}
+
+	args := []struct {
+		query    string
+		expected []*models.Session
+	}{
+		{fmt.Sprintf("room_id=%d", rooms[0].ID), []*models.Session{sessions[0], sessions[2], sessions[4]}},
+		{fmt.Sprintf("gateway_id=%d&gateway_id=%d", gateways[0].ID, gateways[1].ID), sessions},
+		{fmt.Sprintf("gateway_id=%d&removed=false", gateways[0].ID), []*models.Session{sessions[2], sessions[4]}},
+		{fmt.Sprintf("accounts_id=%s&accounts_id=%s", users[3].AccountsID, users[5].AccountsID), []*models.Session{sessions[3], sessions[5]}},
+		{"removed=true", []*models.Session{sessions[0]}},
+		{"question=true", []*models.Session{sessions[1]}},
+		{"camera=false", []*models.Session{sessions[2]}},
+		{"ip=10.0.0.8", []*models.Session{sessions[2]}},
+		{"ip=10.0.0.0/24", []*models.Session{sessions[2]}},
+		{fmt.Sprintf("removed_from=%s", sessions[0].RemovedAt.Time.Add(-time.Minute).Format(time.RFC3339)), []*models.Session{sessions[0]}},
+		{fmt.Sprintf("created_to=%s", sessions[0].CreatedAt.Add(-time.Minute).Format(time.RFC3339)), []*models.Session{}},
+	}
+
+	for i, tc := range args {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/sessions?%s", tc.query), nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		body := s.request200json(req)
+		s.Equal(len(tc.expected), int(body["total"].(float64)), "total %d", i)
+		data := body["data"].([]interface{})
+		s.Require().Equal(len(tc.expected), len(data), "len(data) %d", i)
+
+		expectedIDs := make(map[int64]bool, len(tc.expected))
+		for _, session := range tc.expected {
+			expectedIDs[session.ID] = true
+		}
+		for _, x := range data {
+			id := int64(x.(map[string]interface{})["id"].(float64))
+			s.True(expectedIDs[id], "unexpected session %d in %d", id, i)
+		}
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionForbidden() {
+	req, _ := http.NewRequest("POST", "/admin/sessions/1/kick", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("POST", "/admin/sessions/1/kick", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionNotFound() {
+	req, _ := http.NewRequest("POST", "/admin/sessions/abc/kick", nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest("POST", "/admin/sessions/1/kick", nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest("POST", "/admin/users/unknown/kick", nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionBadRequest() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), bytes.NewBuffer([]byte("{\"block_minutes\": -1}")))
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code)
+
+	session.RemovedAt = null.TimeFrom(time.Now().UTC())
+	_, err := session.Update(s.DB, boil.Infer())
+	s.Require().NoError(err)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "inactive session")
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/admin/users/%s/kick", user.AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "no active sessions")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSession() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	janusAdminAPI.On("MessagePlugin", mock.MatchedBy(func(r *domain.VideoroomKickRequest) bool {
+		return r.RoomID == room.GatewayUID && r.ID == session.GatewayFeed.Int64
+	})).Return(&janus_admin.MessagePluginResponse{}, nil).Once()
+
+	body, _ := json.Marshal(KickRequest{Comment: "test", BlockMinutes: 10})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), bytes.NewBuffer(body))
+	s.apiAuthP(req, []string{common.RoleShidur})
+	s.request200json(req)
+	janusAdminAPI.AssertExpectations(s.T())
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(session.RemovedAt.Valid, "removed_at")
+
+	events, err := session.SessionEvents().All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "session events")
+	s.Equal(common.SessionEventClose, events[0].Type, "event type")
+	s.Equal(common.SessionEventSourceAdmin, events[0].Source, "event source")
+	var props map[string]interface{}
+	s.Require().NoError(events[0].Properties.Unmarshal(&props))
+	s.Equal("kick", props["reason"], "reason")
+	s.Equal("test", props["comment"], "comment")
+	s.NotNil(props["block_until"], "block_until")
+
+	// re-entry is blocked
+	bans, err := models.UserBans(models.UserBanWhere.UserID.EQ(user.ID)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(bans, 1, "user bans")
+	s.Equal(room.ID, bans[0].RoomID.Int64, "ban room")
+	s.Equal("kick: test", bans[0].Reason.String, "ban reason")
+	ban, ok := s.app.cache.bans.Find(user.ID, room.ID)
+	s.Require().True(ok, "bans room")
+	s.Equal(bans[0].ID, ban.ID, "cached ban")
+
+	v1User := s.makeV1user(gateway, room, user)
+	b, _ := json.Marshal(v1User)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/users/%s", user.AccountsID), bytes.NewBuffer(b))
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Equal(http.StatusBadRequest, resp.Code, "blocked heartbeat")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickUser() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	janusAdminAPI.On("MessagePlugin", mock.Anything).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 428, Reason: "No such feed"}}, nil).Once()
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/users/%s/kick", user.AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	s.request200json(req)
+	janusAdminAPI.AssertExpectations(s.T())
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(session.RemovedAt.Valid, "removed_at")
+
+	count, err := models.UserBans(models.UserBanWhere.UserID.EQ(user.ID)).Count(s.DB)
+	s.Require().NoError(err)
+	s.EqualValues(0, count, "no user ban")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionOnly() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	other := s.CreateSession(user, gateway, s.CreateRoom(gateway))
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	janusAdminAPI.On("MessagePlugin", mock.Anything).Return(&janus_admin.MessagePluginResponse{}, nil).Once()
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	s.request200json(req)
+	janusAdminAPI.AssertExpectations(s.T())
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(session.RemovedAt.Valid, "kicked session removed_at")
+	s.Require().NoError(other.Reload(s.DB))
+	s.False(other.RemovedAt.Valid, "other session removed_at")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionRefused() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	janusAdminAPI.On("MessagePlugin", mock.Anything).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 433, Reason: "Unauthorized (wrong secret)"}}, nil).Once()
+
+	body, _ := json.Marshal(KickRequest{BlockMinutes: 10})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), bytes.NewBuffer(body))
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadGateway, resp.Code)
+	var kResp KickResponse
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &kResp))
+	s.Require().Len(kResp.Failed, 1, "failed sessions")
+	s.Equal(session.ID, kResp.Failed[0].Session, "failed session")
+	janusAdminAPI.AssertExpectations(s.T())
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.False(session.RemovedAt.Valid, "refused session removed_at")
+
+	count, err := models.UserBans(models.UserBanWhere.UserID.EQ(user.ID)).Count(s.DB)
+	s.Require().NoError(err)
+	s.EqualValues(0, count, "no user ban when nothing was kicked")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionNoRoomBlock() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, s.CreateRoom(gateway))
+	session.RoomID = null.Int64{}
+	_, err := session.Update(s.DB, boil.Whitelist(models.SessionColumns.RoomID))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	body, _ := json.Marshal(KickRequest{BlockMinutes: 10})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), bytes.NewBuffer(body))
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code)
+	janusAdminAPI.AssertNotCalled(s.T(), "MessagePlugin", mock.Anything)
+
+	count, err := models.UserBans(models.UserBanWhere.UserID.EQ(user.ID)).Count(s.DB)
+	s.Require().NoError(err)
+	s.EqualValues(0, count, "no user ban")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickSessionNoFeed() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	session.GatewayFeed = null.Int64{}
+	_, err := session.Update(s.DB, boil.Whitelist(models.SessionColumns.GatewayFeed))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/sessions/%d/kick", session.ID), nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadGateway, resp.Code)
+	var kResp KickResponse
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &kResp))
+	s.Require().Len(kResp.Failed, 1, "failed sessions")
+	s.Equal(session.ID, kResp.Failed[0].Session, "failed session")
+	janusAdminAPI.AssertNotCalled(s.T(), "MessagePlugin", mock.Anything)
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.False(session.RemovedAt.Valid, "session removed_at")
+}
+
+func (s *ApiTestSuite) TestAdmin_KickUserGatewayFailure() {
+	janusAdminAPI := new(mocks.AdminAPI)
+	gateway := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+	failingAPI := new(mocks.AdminAPI)
+	failing := s.CreateGateway()
+	domain.GatewayAdminAPIRegistry.Set(failing, failingAPI)
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	failingSession := s.CreateSession(user, failing, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	janusAdminAPI.On("MessagePlugin", mock.Anything).Return(&janus_admin.MessagePluginResponse{}, nil).Once()
+	failingAPI.On("MessagePlugin", mock.Anything).Return(nil, errors.New("gateway is down")).Once()
+
+	body, _ := json.Marshal(KickRequest{BlockMinutes: 10})
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/users/%s/kick", user.AccountsID), bytes.NewBuffer(body))
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadGateway, resp.Code)
+	var kResp KickResponse
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &kResp))
+	s.Require().Len(kResp.Failed, 1, "failed sessions")
+	s.Equal(failingSession.ID, kResp.Failed[0].Session, "failed session")
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(session.RemovedAt.Valid, "kicked session removed_at")
+	s.Require().NoError(failingSession.Reload(s.DB))
+	s.False(failingSession.RemovedAt.Valid, "failed session removed_at")
+
+	count, err := models.UserBans(models.UserBanWhere.UserID.EQ(user.ID)).Count(s.DB)
+	s.Require().NoError(err)
+	s.EqualValues(1, count, "user ban")
+}
+
+func (s *ApiTestSuite) TestAdmin_ListUsersForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/users", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/users", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListUsersBadRequest() {
+	args := [...]string{
+		"page_no=0",
+		"page_size=abc",
+		"disabled=abc",
+		"removed=abc",
+	}
+	for i, query := range args {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/users?%s", query), nil)
+		s.apiAuthP(req, []string{common.RoleRoot})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusBadRequest, resp.Code, i)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_ListUsers() {
+	req, _ := http.NewRequest("GET", "/admin/users", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.Equal(0, int(body["total"].(float64)), "total")
+	s.Equal(0, len(body["data"].([]interface{})), "len(data)")
+
+	users := make([]*models.User, 5)
+	for i := range users {
+		users[i] = s.CreateUser()
+	}
+	users[1].Disabled = true
+	_, err := users[1].Update(s.DB, boil.Whitelist(models.UserColumns.Disabled))
+	s.Require().NoError(err)
+	users[2].RemovedAt = null.TimeFrom(time.Now().UTC())
+	_, err = users[2].Update(s.DB, boil.Whitelist(models.UserColumns.RemovedAt))
+	s.Require().NoError(err)
+
+	req, _ = http.NewRequest("GET", "/admin/users", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(5, int(body["total"].(float64)), "total")
+	s.Equal(5, len(body["data"].([]interface{})), "len(data)")
+
+	req, _ = http.NewRequest("GET", "/admin/users?disabled=true", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(1, int(body["total"].(float64)), "total")
+	s.Equal(users[1].AccountsID, body["data"].([]interface{})[0].(map[string]interface{})["accounts_id"], "disabled")
+
+	req, _ = http.NewRequest("GET", "/admin/users?removed=false&disabled=false", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(3, int(body["total"].(float64)), "total")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/users?term=%s", users[3].AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(1, int(body["total"].(float64)), "total")
+	s.Equal(users[3].AccountsID, body["data"].([]interface{})[0].(map[string]interface{})["accounts_id"], "term")
+
+	req, _ = http.NewRequest("GET", "/admin/users?page_size=2&page_no=3", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(5, int(body["total"].(float64)), "total")
+	s.Equal(1, len(body["data"].([]interface{})), "len(data)")
+}
+
+func (s *ApiTestSuite) TestAdmin_GetUserNotFound() {
+	req, _ := http.NewRequest("GET", "/admin/users/abc", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/users/0", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_GetUser() {
+	user := s.CreateUser()
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/users/%d", user.ID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.EqualValues(user.ID, body["id"], "id")
+	s.Equal(user.AccountsID, body["accounts_id"], "accounts_id")
+}
+
+func (s *ApiTestSuite) TestAdmin_UpdateUserForbidden() {
+	req, _ := http.NewRequest("PUT", "/admin/users/1", nil)
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_UpdateUser() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	v1User := s.makeV1user(gateway, room, user)
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+
+	payload := map[string]interface{}{
+		"first_name": "first",
+		"last_name":  "last",
+		"email":      user.Email.String,
+		"username":   user.Username.String,
+		"disabled":   true,
+	}
+	b, _ := json.Marshal(payload)
+	req, _ := http.NewRequest("PUT", fmt.Sprintf("/admin/users/%d", user.ID), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.Equal("first", body["first_name"], "first_name")
+	s.Equal("last", body["last_name"], "last_name")
+	s.Equal(true, body["disabled"], "disabled")
+
+	s.Require().NoError(user.Reload(s.DB))
+	s.True(user.Disabled, "disabled")
+	s.Equal("first", user.FirstName.String, "first_name")
+
+	// disabled user is rejected right away
+	_, ok := s.app.cache.users.ByAccountsID(user.AccountsID)
+	s.False(ok, "user cache")
+	s.Error(s.app.sessionManager.UpsertSession(context.TODO(), v1User), "UpsertSession disabled user")
+
+	// only fields sent are updated
+	b, _ = json.Marshal(map[string]interface{}{"last_name": ""})
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/admin/users/%d", user.ID), bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Nil(body["last_name"], "cleared last_name")
+
+	s.Require().NoError(user.Reload(s.DB))
+	s.False(user.LastName.Valid, "last_name")
+	s.True(user.Disabled, "still disabled")
+	s.Equal("first", user.FirstName.String, "unchanged first_name")
+}
+
+func (s *ApiTestSuite) TestAdmin_DeleteUser() {
+	user := s.CreateUser()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/admin/users/%d", user.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	s.request200json(req)
+
+	s.Require().NoError(user.Reload(s.DB))
+	s.True(user.RemovedAt.Valid, "removed_at")
+	_, ok := s.app.cache.users.ByAccountsID(user.AccountsID)
+	s.False(ok, "user cache")
+}
+
+func (s *ApiTestSuite) TestAdmin_BansForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/bans", nil)
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("POST", "/admin/bans", nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("DELETE", "/admin/bans/1", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_CreateBanBadRequest() {
+	user := s.CreateUser()
+
+	payloads := []BanRequest{
+		{},
+		{UserID: user.ID},
+		{UserID: user.ID, Minutes: -1},
+		{UserID: user.ID, Until: time.Now().Add(-time.Minute)},
+		{UserID: user.ID, Minutes: 10, Until: time.Now().Add(time.Hour)},
+		{UserID: user.ID, Minutes: 10, Reason: stringutil.GenerateName(256)},
+		{UserID: user.ID + 1000, Minutes: 10},
+		{UserID: user.ID, Minutes: 10, RoomID: null.Int64From(math.MaxInt32)},
+	}
+	for i, payload := range payloads {
+		b, _ := json.Marshal(payload)
+		req, _ := http.NewRequest("POST", "/admin/bans", bytes.NewBuffer(b))
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Equal(http.StatusBadRequest, resp.Code, i)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_Bans() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	v1User := s.makeV1user(gateway, room, user)
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+
+	// room ban
+	b, _ := json.Marshal(BanRequest{UserID: user.ID, RoomID: null.Int64From(room.ID), Reason: "spam", Minutes: 10})
+	req, _ := http.NewRequest("POST", "/admin/bans", bytes.NewBuffer(b))
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request201json(req)
+	roomBanID := int64(body["id"].(float64))
+	s.Equal("spam", body["reason"], "reason")
+	s.Equal("Subject", body["banned_by"], "banned_by")
+
+	b, _ = json.Marshal(v1User)
+	req, _ = http.NewRequest("PUT", fmt.Sprintf("/users/%s", user.AccountsID), bytes.NewBuffer(b))
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code, "banned heartbeat")
+	s.Contains(resp.Body.String(), "Banned user", "ban error")
+	s.Contains(resp.Body.String(), "spam", "ban reason")
+
+	// other rooms are allowed
+	otherRoom := s.CreateRoom(gateway)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	s.NoError(s.app.sessionManager.UpsertSession(context.TODO(), s.makeV1user(gateway, otherRoom, user)))
+
+	// global ban
+	global := &models.UserBan{UserID: user.ID, Until: time.Now().UTC().Add(time.Minute)}
+	s.Require().NoError(global.Insert(s.DB, boil.Infer()))
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	s.Error(s.app.sessionManager.UpsertSession(context.TODO(), s.makeV1user(gateway, otherRoom, user)), "global ban")
+
+	// expired bans lift without a reload
+	global.Until = time.Now().UTC().Add(-time.Second)
+	_, err := global.Update(s.DB, boil.Whitelist(models.UserBanColumns.Until))
+	s.Require().NoError(err)
+	bans := &BanCache{m: map[int64][]*models.UserBan{user.ID: {global}}}
+	_, ok := bans.Find(user.ID, room.ID)
+	s.False(ok, "expired ban")
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	s.NoError(s.app.sessionManager.UpsertSession(context.TODO(), s.makeV1user(gateway, otherRoom, user)), "expired ban")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/bans?user_id=%d", user.ID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(2, int(body["total"].(float64)), "total")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/bans?user_id=%d&active=true", user.ID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(1, int(body["total"].(float64)), "active total")
+	s.EqualValues(roomBanID, body["data"].([]interface{})[0].(map[string]interface{})["id"], "active ban")
+
+	// lift
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/bans/%d", roomBanID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.NotNil(body["lifted_at"], "lifted_at")
+	s.Equal("Subject", body["lifted_by"], "lifted_by")
+	s.NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User), "lifted ban")
+
+	req, _ = http.NewRequest("DELETE", fmt.Sprintf("/admin/bans/%d", roomBanID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp = s.request(req)
+	s.Equal(http.StatusNotFound, resp.Code, "lift twice")
+}
+
+func (s *ApiTestSuite) TestAdmin_ExportUser() {
+	user := s.CreateUser()
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/users/%s/export", user.AccountsID), nil)
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/users/unknown/export", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/users/%s/export", user.AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.Equal(user.AccountsID, body["user"].(map[string]interface{})["accounts_id"], "user")
+	s.Empty(body["sessions"], "sessions")
+}
+
+func (s *ApiTestSuite) TestAdmin_EraseUser() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/users/%s/erase", user.AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/admin/users/%s/erase", user.AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request200json(req)
+	s.NotEqual(float64(user.ID), body["id"], "id")
+	s.NotEqual(user.AccountsID, body["accounts_id"], "accounts_id")
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.EqualValues(body["id"], session.UserID, "session user")
+	s.True(session.RemovedAt.Valid, "session closed")
+	s.False(session.IPAddress.Valid, "ip_address")
+
+	_, ok := s.app.cache.users.ByAccountsID(user.AccountsID)
+	s.False(ok, "user cache")
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessionEventsForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/sessions/1/events", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/sessions/1/events", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessionEventsNotFound() {
+	req, _ := http.NewRequest("GET", "/admin/sessions/abc/events", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/sessions/1/events", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessionEventsBadRequest() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	session := s.CreateSession(s.CreateUser(), gateway, room)
+
+	args := [...]string{
+		"page_no=0",
+		"page_size=abc",
+		"from=abc",
+		"to=2020-13-01",
+	}
+	for i, query := range args {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/sessions/%d/events?%s", session.ID, query), nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusBadRequest, resp.Code, i)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_ListSessionEvents() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/sessions/%d/events", session.ID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.Equal(0, int(body["total"].(float64)), "total")
+	s.Equal(0, len(body["data"].([]interface{})), "len(data)")
+
+	eTypes := []string{common.SessionEventEnter, common.SessionEventQuestion, common.SessionEventCamera, common.SessionEventClose}
+	events := make([]*models.SessionEvent, len(eTypes))
+	for i, eType := range eTypes {
+		events[i] = s.createSessionEvent(session, eType, time.Now().UTC().Add(time.Duration(i-len(eTypes))*time.Hour))
+	}
+
+	body = s.request200json(req)
+	s.Equal(len(events), int(body["total"].(float64)), "total")
+	data := body["data"].([]interface{})
+	s.Require().Equal(len(events), len(data), "len(data)")
+	for i, event := range events {
+		eventData := data[i].(map[string]interface{})
+		s.EqualValues(event.ID, eventData["id"], "id %d", i)
+		s.Equal(event.Type, eventData["type"], "type %d", i)
+	}
+
+	// time range
+	from := events[1].CreatedAt.Add(-time.Minute).Format(time.RFC3339)
+	to := events[2].CreatedAt.Add(time.Minute).Format(time.RFC3339)
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/sessions/%d/events?from=%s&to=%s", session.ID, from, to), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(2, int(body["total"].(float64)), "total")
+	data = body["data"].([]interface{})
+	s.Require().Equal(2, len(data), "len(data)")
+	s.EqualValues(events[1].ID, data[0].(map[string]interface{})["id"], "time range first")
+	s.EqualValues(events[2].ID, data[1].(map[string]interface{})["id"], "time range second")
+
+	// type filter
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/sessions/%d/events?type=%s", session.ID, common.SessionEventClose), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(1, int(body["total"].(float64)), "total")
+
+	// by user
+	otherSession := s.CreateSession(user, gateway, room)
+	s.createSessionEvent(otherSession, common.SessionEventEnter, time.Now().UTC())
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/users/%s/events", user.AccountsID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(len(events)+1, int(body["total"].(float64)), "user total")
+
+	req, _ = http.NewRequest("GET", "/admin/users/unknown/events", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListDynamicConfigsForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/dynamic_config", nil)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusUnauthorized, resp.Code)
+
+	req, _ = http.NewRequest("GET", "/admin/dynamic_config", nil)
+	s.apiAuth(req)
+	resp = s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_ListDynamicConfigsBadRequest() {
+	args := [...]string{
+		"page_no=0",
+		"page_no=-1",
+		"page_no=abc",
+		"page_size=0",
+		"page_size=-1",
+		"page_size=abc",
+	}
+	for i, query := range args {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/dynamic_config?%s", query), nil)
+		s.apiAuthP(req, []string{common.RoleRoot})
+		resp := s.request(req)
+		s.Require().Equal(http.StatusBadRequest, resp.Code, i)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_ListDynamicConfigs() {
+	req, _ := http.NewRequest("GET", "/admin/dynamic_config", nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request200json(req)
+	s.Equal(0, int(body["total"].(float64)), "total")
+	s.Equal(0, len(body["data"].([]interface{})), "len(data)")
+
+	kvs := make([]*models.DynamicConfig, 10)
 	for i := range kvs {
 		kvs[i] = s.createDynamicConfig()
 	}
@@ -953,6 +2625,24 @@ func (s *ApiTestSuite) findRoomInGateway(gateway *models.Gateway, id int) *janus
 	return nil
 }
 
+func (s *ApiTestSuite) findTextroomInGateway(gateway *models.Gateway, id int) *janus_plugins.TextroomRoomFromListResponse {
+	api, err := domain.GatewayAdminAPIRegistry.For(gateway)
+	s.Require().NoError(err, "Admin API for gateway")
+
+	request := janus_plugins.MakeTextroomRequestFactory(common.Config.GatewayPluginAdminKey).ListRequest()
+	resp, err := api.MessagePlugin(request)
+	s.Require().NoError(err, "api.MessagePlugin")
+
+	tResp, _ := resp.(*janus_plugins.TextroomListResponse)
+	for _, x := range tResp.Rooms {
+		if x.Room == id {
+			return x
+		}
+	}
+
+	return nil
+}
+
 func (s *ApiTestSuite) createDynamicConfig() *models.DynamicConfig {
 	kv := &models.DynamicConfig{
 		Key:       fmt.Sprintf("key_%s", stringutil.GenerateName(6)),
@@ -962,3 +2652,15 @@ func (s *ApiTestSuite) createDynamicConfig() *models.DynamicConfig {
 	s.Require().NoError(kv.Insert(s.DB, boil.Infer()))
 	return kv
 }
+
+func (s *ApiTestSuite) createSessionEvent(session *models.Session, eType string, createdAt time.Time) *models.SessionEvent {
+	event := &models.SessionEvent{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Type:      eType,
+		Source:    common.SessionEventSourceProtocol,
+		CreatedAt: createdAt,
+	}
+	s.Require().NoError(event.Insert(s.DB, boil.Infer()))
+	return event
+}
@@ -2,7 +2,9 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -23,6 +25,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
 	"github.com/Bnei-Baruch/gxydb-api/domain"
@@ -47,6 +50,8 @@ func (s *ApiTestSuite) SetupSuite() {
 	if _, ok := os.LookupEnv("MQTT_BROKER_URL"); !ok {
 		common.Config.MQTTBrokerUrl = "localhost:1883"
 	}
+	common.Config.ReconcileInterval = 0  // tests trigger it explicitly
+	common.Config.AttendanceInterval = 0 // tests trigger it explicitly
 
 	s.app = new(App)
 	s.app.InitializeWithDeps(s.DB, s.tokenVerifier)
@@ -1168,6 +1173,284 @@ func (s *ApiTestSuite) TestHandleEventVideoroomLeavingUnknownUser() {
 	s.True(ok, "new user exists")
 }
 
+func (s *ApiTestSuite) TestHandleEventBatch() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	sessions := make([]*models.Session, 3)
+	for i := range sessions {
+		sessions[i] = s.CreateSession(s.CreateUser(), gateway, room)
+	}
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	events := make([]interface{}, 0)
+	for _, session := range sessions {
+		events = append(events, janus.SessionEvent{
+			BaseEvent: janus.BaseEvent{
+				Emitter:   gateway.Name,
+				Type:      1,
+				Timestamp: time.Now().UTC().Unix(),
+				Session:   uint64(session.GatewaySession.Int64),
+			},
+			Event: janus.SessionEventBody{
+				Name: "destroyed",
+			},
+		})
+	}
+	events = append(events, map[string]interface{}{"type": 7}) // unknown type
+	b, _ := json.Marshal(events)
+
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	body := s.request200json(req)
+	s.EqualValues(4, body["total"], "total")
+	s.EqualValues(3, body["processed"], "processed")
+	failed := body["failed"].([]interface{})
+	s.Require().Len(failed, 1, "failed")
+	s.EqualValues(3, failed[0].(map[string]interface{})["index"], "failed index")
+
+	for _, session := range sessions {
+		s.Require().NoError(session.Reload(s.DB))
+		s.True(session.RemovedAt.Valid, "removed_at")
+	}
+}
+
+func (s *ApiTestSuite) TestHandleEventBatchLargerThanQueue() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	session := s.CreateSession(s.CreateUser(), gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	// events of a single gateway session all go to the same worker queue
+	events := make([]interface{}, 0)
+	for i := 0; i < 2*eventDispatcherQueue; i++ {
+		events = append(events, janus.SessionEvent{
+			BaseEvent: janus.BaseEvent{
+				Emitter:   gateway.Name,
+				Type:      1,
+				Timestamp: time.Now().UTC().Unix(),
+				Session:   uint64(session.GatewaySession.Int64),
+			},
+			Event: janus.SessionEventBody{
+				Name: "created",
+			},
+		})
+	}
+	b, _ := json.Marshal(events)
+
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	body := s.request200json(req)
+	s.EqualValues(2*eventDispatcherQueue, body["total"], "total")
+	s.EqualValues(2*eventDispatcherQueue, body["processed"], "processed")
+	s.Empty(body["failed"], "failed")
+}
+
+func (s *ApiTestSuite) TestHandleEventBatchBadJSON() {
+	gateway := s.CreateGateway()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer([]byte("[{\"type\":1},")))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusBadRequest, resp.Code)
+}
+
+func (s *ApiTestSuite) TestHandleEventSessionTimeout() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	other := s.CreateSession(s.CreateUser(), gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	event := janus.SessionEvent{
+		BaseEvent: janus.BaseEvent{
+			Emitter:   gateway.Name,
+			Type:      1,
+			Timestamp: time.Now().UTC().Unix(),
+			Session:   uint64(session.GatewaySession.Int64),
+		},
+		Event: janus.SessionEventBody{
+			Name: "timeout",
+		},
+	}
+	b, _ := json.Marshal(event)
+
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	s.request200json(req)
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(session.RemovedAt.Valid, "removed_at")
+	var props map[string]interface{}
+	s.Require().NoError(session.Properties.Unmarshal(&props))
+	s.Equal("timeout", props["close_reason"], "close_reason")
+
+	s.Require().NoError(other.Reload(s.DB))
+	s.False(other.RemovedAt.Valid, "other removed_at")
+
+	events, err := models.SessionEvents(models.SessionEventWhere.SessionID.EQ(session.ID)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "session events")
+	s.Equal(common.SessionEventClose, events[0].Type, "event type")
+	s.Equal(common.SessionEventSourceGateway, events[0].Source, "event source")
+}
+
+func (s *ApiTestSuite) TestHandleEventHandleDetached() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	event := janus.HandleEvent{
+		BaseEvent: janus.BaseEvent{
+			Emitter:   gateway.Name,
+			Type:      2,
+			Timestamp: time.Now().UTC().Unix(),
+			Session:   uint64(session.GatewaySession.Int64),
+			Handle:    uint64(session.GatewayHandle.Int64) + 1,
+		},
+		Event: janus.HandleEventBody{
+			Name:   "detached",
+			Plugin: "janus.plugin.videoroom",
+		},
+	}
+	b, _ := json.Marshal(event)
+
+	// some other handle
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	s.request200json(req)
+	s.Require().NoError(session.Reload(s.DB))
+	s.False(session.RemovedAt.Valid, "removed_at")
+
+	// our handle
+	event.Handle = uint64(session.GatewayHandle.Int64)
+	b, _ = json.Marshal(event)
+	req, _ = http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	s.request200json(req)
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(session.RemovedAt.Valid, "removed_at")
+}
+
+func (s *ApiTestSuite) TestHandleEventVideoroomJoined() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	stale := s.CreateSession(user, gateway, room)
+	otherRoom := s.CreateSession(user, gateway, s.CreateRoom(gateway))
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	v1User := s.makeV1user(gateway, room, user)
+	v1UserJson, _ := json.Marshal(v1User)
+	event := janus.PluginEvent{
+		BaseEvent: janus.BaseEvent{
+			Emitter:   gateway.Name,
+			Type:      64,
+			Timestamp: time.Now().UTC().Unix(),
+			Session:   uint64(v1User.Session),
+			Handle:    uint64(v1User.Handle),
+		},
+		Event: janus.PluginEventBody{
+			Plugin: "janus.plugin.videoroom",
+			Data: map[string]interface{}{
+				"event":   "joined",
+				"room":    room.GatewayUID,
+				"id":      12345,
+				"display": string(v1UserJson),
+			},
+		},
+	}
+	b, _ := json.Marshal(event)
+
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	s.request200json(req)
+
+	s.Require().NoError(stale.Reload(s.DB))
+	s.True(stale.RemovedAt.Valid, "stale session in room")
+	s.Require().NoError(otherRoom.Reload(s.DB))
+	s.False(otherRoom.RemovedAt.Valid, "session in other room")
+
+	session, err := models.Sessions(
+		models.SessionWhere.UserID.EQ(user.ID),
+		models.SessionWhere.GatewaySession.EQ(null.Int64From(v1User.Session))).One(s.DB)
+	s.Require().NoError(err)
+	s.False(session.RemovedAt.Valid, "removed_at")
+	s.Equal(room.ID, session.RoomID.Int64, "room_id")
+	s.Equal(gateway.ID, session.GatewayID.Int64, "gateway_id")
+	s.Equal(v1User.Session, session.GatewaySession.Int64, "gateway_session")
+	s.Equal(v1User.Handle, session.GatewayHandle.Int64, "gateway_handle")
+	s.EqualValues(12345, session.GatewayFeed.Int64, "gateway_feed")
+
+	events, err := models.SessionEvents(models.SessionEventWhere.SessionID.EQ(session.ID)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "session events")
+	s.Equal(common.SessionEventEnter, events[0].Type, "event type")
+	s.Equal(common.SessionEventSourceGateway, events[0].Source, "event source")
+}
+
+func (s *ApiTestSuite) TestHandleEventVideoroomConfigured() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	s.Require().True(session.Camera, "camera")
+
+	event := janus.PluginEvent{
+		BaseEvent: janus.BaseEvent{
+			Emitter:   gateway.Name,
+			Type:      64,
+			Timestamp: time.Now().UTC().Unix(),
+			Session:   uint64(session.GatewaySession.Int64),
+			Handle:    uint64(session.GatewayHandle.Int64),
+		},
+		Event: janus.PluginEventBody{
+			Plugin: "janus.plugin.videoroom",
+			Data: map[string]interface{}{
+				"event":        "configured",
+				"room":         room.GatewayUID,
+				"id":           session.GatewayFeed.Int64,
+				"audio_active": true,
+				"video_active": false,
+			},
+		},
+	}
+	b, _ := json.Marshal(event)
+
+	req, _ := http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	s.request200json(req)
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.False(session.Camera, "camera")
+	s.True(session.UpdatedAt.Valid, "updated_at")
+
+	events, err := models.SessionEvents(models.SessionEventWhere.SessionID.EQ(session.ID)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "session events")
+	s.Equal(common.SessionEventCamera, events[0].Type, "event type")
+	s.Equal("false", events[0].NewValue.String, "event new_value")
+
+	// talking changes nothing, the session was just updated
+	updatedAt := session.UpdatedAt.Time
+	event.Event.Data = map[string]interface{}{
+		"videoroom": "talking",
+		"room":      room.GatewayUID,
+		"id":        session.GatewayFeed.Int64,
+	}
+	b, _ = json.Marshal(event)
+	req, _ = http.NewRequest("POST", "/event", bytes.NewBuffer(b))
+	req.SetBasicAuth(gateway.Name, gateway.Name)
+	s.request200json(req)
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.True(updatedAt.Equal(session.UpdatedAt.Time), "talking updated_at")
+}
+
 func (s *ApiTestSuite) TestHandleProtocolBadJSON() {
 	gateway := s.CreateGateway()
 	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
@@ -1441,6 +1724,16 @@ func (s *ApiTestSuite) TestHandleProtocolQuestion() {
 
 	s.Require().NoError(session.Reload(s.DB))
 	s.False(session.Question, "question false")
+
+	events, err := session.SessionEvents(qm.OrderBy("id")).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 2, "session events")
+	for i, expected := range []bool{true, false} {
+		s.Equal(common.SessionEventQuestion, events[i].Type, "event type %d", i)
+		s.Equal(common.SessionEventSourceProtocol, events[i].Source, "event source %d", i)
+		s.Equal(strconv.FormatBool(!expected), events[i].OldValue.String, "event old value %d", i)
+		s.Equal(strconv.FormatBool(expected), events[i].NewValue.String, "event new value %d", i)
+	}
 }
 
 func (s *ApiTestSuite) TestHandleProtocolCamera() {
@@ -1743,6 +2036,98 @@ func (s *ApiTestSuite) TestMQTTHandleServiceProtocolAudioOut() {
 	s.Equal(1, int(statsObj["on_air"].(float64)), "on_air")
 }
 
+func (s *ApiTestSuite) TestMQTTPublishRoomEvents() {
+	user := s.CreateUser()
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(common.Config.MQTTBrokerUrl).
+		SetClientID(fmt.Sprintf("gxydb-api_%d", rand.Intn(1024)))
+	client := mqtt.NewClient(opts)
+
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		s.FailNow("MQTT connect error ", token.Error())
+	}
+	defer client.Disconnect(100)
+
+	userMsgs := make(chan mqtt.Message, 10)
+	roomMsgs := make(chan mqtt.Message, 10)
+	userTopic := fmt.Sprintf("galaxy/users/%s", user.AccountsID)
+	roomTopic := fmt.Sprintf("galaxy/rooms/%d", room.GatewayUID)
+	if token := client.Subscribe(userTopic, byte(1), func(c mqtt.Client, m mqtt.Message) { userMsgs <- m }); token.Wait() && token.Error() != nil {
+		s.FailNow("MQTT Subscribe error ", token.Error())
+	}
+	if token := client.Subscribe(roomTopic, byte(1), func(c mqtt.Client, m mqtt.Message) { roomMsgs <- m }); token.Wait() && token.Error() != nil {
+		s.FailNow("MQTT Subscribe error ", token.Error())
+	}
+
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), s.makeV1user(gateway, room, user)))
+
+	select {
+	case m := <-userMsgs:
+		var event V2RoomEvent
+		s.Require().NoError(json.Unmarshal(m.Payload(), &event))
+		s.Equal(RoomEventEnter, event.Type, "event.Type")
+		s.Equal(room.GatewayUID, event.Room, "event.Room")
+	case <-time.After(5 * time.Second):
+		s.FailNow("timeout waiting for user event")
+	}
+
+	select {
+	case m := <-roomMsgs:
+		var state V1Room
+		s.Require().NoError(json.Unmarshal(m.Payload(), &state))
+		s.Equal(1, state.NumUsers, "state.NumUsers")
+		s.Require().Len(state.Users, 1, "state.Users")
+		s.Equal(user.AccountsID, state.Users[0].ID, "state.Users[0].ID")
+	case <-time.After(5 * time.Second):
+		s.FailNow("timeout waiting for room state")
+	}
+}
+
+func (s *ApiTestSuite) TestMQTTPublishMigrateHints() {
+	gateway := s.CreateGateway()
+	other := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	s.CreateSession(user, gateway, room)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(common.Config.MQTTBrokerUrl).
+		SetClientID(fmt.Sprintf("gxydb-api_%d", rand.Intn(1024)))
+	client := mqtt.NewClient(opts)
+
+	if token := client.Connect(); token.Wait() && token.Error() != nil {
+		s.FailNow("MQTT connect error ", token.Error())
+	}
+	defer client.Disconnect(100)
+
+	userMsgs := make(chan mqtt.Message, 10)
+	userTopic := fmt.Sprintf("galaxy/users/%s", user.AccountsID)
+	if token := client.Subscribe(userTopic, byte(1), func(c mqtt.Client, m mqtt.Message) { userMsgs <- m }); token.Wait() && token.Error() != nil {
+		s.FailNow("MQTT Subscribe error ", token.Error())
+	}
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/admin/gateways/%d/drain", gateway.ID), nil)
+	s.apiAuthP(req, []string{common.RoleRoot})
+	body := s.request200json(req)
+	s.Equal(1, int(body["hints"].(float64)), "hints")
+
+	select {
+	case m := <-userMsgs:
+		var event V2RoomEvent
+		s.Require().NoError(json.Unmarshal(m.Payload(), &event))
+		s.Equal(RoomEventMigrate, event.Type, "event.Type")
+		s.Equal(room.GatewayUID, event.Room, "event.Room")
+		s.Equal(other.Name, event.Gateway, "event.Gateway")
+	case <-time.After(5 * time.Second):
+		s.FailNow("timeout waiting for migrate hint")
+	}
+}
+
 func (s *ApiTestSuite) TestV2GetConfig() {
 	janusAdminAPI := new(mocks.AdminAPI)
 	roomsGateways := make(map[string]*models.Gateway)
@@ -1812,6 +2197,127 @@ func (s *ApiTestSuite) TestV2GetConfig() {
 	janusAdminAPI.AssertNumberOfCalls(s.T(), "AddToken", 2*len(roomsGateways))
 }
 
+func (s *ApiTestSuite) TestV2GetConfigUnhealthyGateways() {
+	healthy := s.CreateGateway()
+	unhealthy := s.CreateGateway()
+	streaming := s.CreateGatewayP(common.GatewayTypeStreaming, "admin_url", "janusoverlord")
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	for _, gateway := range []*models.Gateway{unhealthy, streaming} {
+		for i := 0; i < common.Config.GatewayFailureLimit; i++ {
+			domain.GatewayHealthRegistry.ReportFailure(gateway, time.Millisecond, errors.New("timeout"))
+		}
+		defer domain.GatewayHealthRegistry.Remove(gateway)
+	}
+
+	req, _ := http.NewRequest("GET", "/v2/config", nil)
+	s.apiAuth(req)
+	body := s.request200json(req)
+
+	gateways := body["gateways"].(map[string]interface{})
+	roomsGateways := gateways[common.GatewayTypeRooms].(map[string]interface{})
+	s.Len(roomsGateways, 1, "rooms gateways")
+	s.Contains(roomsGateways, healthy.Name, "healthy gateway")
+	s.NotContains(roomsGateways, unhealthy.Name, "unhealthy gateway")
+
+	// no healthy gateway of this type, keep them flagged
+	streamingGateways := gateways[common.GatewayTypeStreaming].(map[string]interface{})
+	s.Require().Contains(streamingGateways, streaming.Name, "only streaming gateway")
+	s.Equal(true, streamingGateways[streaming.Name].(map[string]interface{})["unhealthy"], "flagged unhealthy")
+}
+
+func (s *ApiTestSuite) TestV2Assign() {
+	req, _ := http.NewRequest("GET", "/v2/assign?room=1", nil)
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code, "unknown room")
+
+	gateway1 := s.CreateGateway()
+	gateway2 := s.CreateGateway()
+	s.CreateGatewayP(common.GatewayTypeStreaming, "admin_url", "janusoverlord")
+	room := s.CreateRoom(gateway1)
+	otherRoom := s.CreateRoom(gateway1)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	assign := func(expected *models.Gateway, reason string, msg string) {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/v2/assign?room=%d", room.GatewayUID), nil)
+		s.apiAuth(req)
+		body := s.request200json(req)
+		s.Equalf(expected.Name, body["gateway"].(map[string]interface{})["name"], "%s: gateway", msg)
+		s.Equalf(reason, body["reason"], "%s: reason", msg)
+	}
+
+	// the room's default gateway is configuration, assignments never change it
+	defaultGateway := func() {
+		s.Require().NoError(room.Reload(s.DB))
+		s.Equal(gateway1.ID, room.DefaultGatewayID, "default gateway")
+	}
+
+	forget := func() {
+		s.app.roomAssignments.lock.Lock()
+		delete(s.app.roomAssignments.byRoom, room.ID)
+		s.app.roomAssignments.lock.Unlock()
+	}
+
+	assign(gateway1, AssignReasonDefault, "empty room, default gateway")
+	assign(gateway1, AssignReasonRoom, "pending assignment")
+	forget()
+
+	s.CreateSession(s.CreateUser(), gateway1, otherRoom)
+	s.CreateSession(s.CreateUser(), gateway1, otherRoom)
+	assign(gateway1, AssignReasonDefault, "empty room, loaded default gateway")
+	forget()
+
+	gateway1.MaxSessions = null.IntFrom(2)
+	_, err := gateway1.Update(s.DB, boil.Whitelist(models.GatewayColumns.MaxSessions))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	assign(gateway2, AssignReasonLoad, "default gateway at capacity")
+	defaultGateway()
+	assign(gateway2, AssignReasonRoom, "room sticks to its assigned gateway")
+	forget()
+
+	session := s.CreateSession(s.CreateUser(), gateway1, room)
+	assign(gateway1, AssignReasonRoom, "room already on gateway")
+	_, err = session.Delete(s.DB)
+	s.Require().NoError(err)
+	forget()
+
+	s.CreateSession(s.CreateUser(), gateway2, otherRoom)
+	gateway2.MaxSessions = null.IntFrom(1)
+	_, err = gateway2.Update(s.DB, boil.Whitelist(models.GatewayColumns.MaxSessions))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	assign(gateway2, AssignReasonOverflow, "all gateways at capacity")
+	forget()
+
+	gateway1.MaxSessions = null.Int{}
+	_, err = gateway1.Update(s.DB, boil.Whitelist(models.GatewayColumns.MaxSessions))
+	s.Require().NoError(err)
+	gateway2.MaxSessions = null.Int{}
+	gateway2.Region = null.StringFrom("us")
+	_, err = gateway2.Update(s.DB, boil.Whitelist(models.GatewayColumns.MaxSessions, models.GatewayColumns.Region))
+	s.Require().NoError(err)
+	room.Region = null.StringFrom("us")
+	_, err = room.Update(s.DB, boil.Whitelist(models.RoomColumns.Region))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+	assign(gateway2, AssignReasonLoad, "gateway in room region")
+	defaultGateway()
+	forget()
+
+	// users of a room whose gateway went down all move to the same gateway
+	s.CreateSession(s.CreateUser(), gateway2, room)
+	for i := 0; i < common.Config.GatewayFailureLimit; i++ {
+		domain.GatewayHealthRegistry.ReportFailure(gateway2, time.Millisecond, errors.New("timeout"))
+	}
+	defer domain.GatewayHealthRegistry.Remove(gateway2)
+	assign(gateway1, AssignReasonDefault, "unhealthy gateway")
+	s.CreateSession(s.CreateUser(), gateway2, otherRoom)
+	assign(gateway1, AssignReasonRoom, "unhealthy gateway, next user")
+	defaultGateway()
+}
+
 func (s *ApiTestSuite) TestV2GetRoomsStatistics() {
 	gateway := s.CreateGateway()
 	rooms := make([]*models.Room, 5)
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -139,6 +140,17 @@ func (a *App) V1CreateGroup(w http.ResponseWriter, r *http.Request) {
 }
 
 func (a *App) V1ListRooms(w http.ResponseWriter, r *http.Request) {
+	respRooms, err := a.activeV1Rooms()
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, respRooms)
+}
+
+// activeV1Rooms returns all rooms having users in them, ordered by their first session
+func (a *App) activeV1Rooms() ([]*V1Room, error) {
 	rooms, err := models.Rooms(
 		models.RoomWhere.Disabled.EQ(false),
 		models.RoomWhere.RemovedAt.IsNull(),
@@ -147,8 +159,7 @@ func (a *App) V1ListRooms(w http.ResponseWriter, r *http.Request) {
 	).All(a.DB)
 
 	if err != nil {
-		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
-		return
+		return nil, pkgerr.WithStack(err)
 	}
 
 	respRooms := make([]*V1Room, 0)
@@ -166,7 +177,26 @@ func (a *App) V1ListRooms(w http.ResponseWriter, r *http.Request) {
 		return respRooms[i].firstSessionInRoom.Before(respRooms[j].firstSessionInRoom)
 	})
 
-	httputil.RespondWithJSON(w, http.StatusOK, respRooms)
+	return respRooms, nil
+}
+
+// v1RoomState returns the current state of a room, empty rooms included
+func (a *App) v1RoomState(id int64) (*V1Room, error) {
+	room, err := models.Rooms(
+		models.RoomWhere.ID.EQ(id),
+		qm.Load(models.RoomRels.Sessions, models.SessionWhere.RemovedAt.IsNull()),
+		qm.Load(qm.Rels(models.RoomRels.Sessions, models.SessionRels.User)),
+	).One(a.DB)
+	if err != nil {
+		return nil, pkgerr.WithStack(err)
+	}
+
+	respRoom := a.makeV1Room(room, nil)
+	if respRoom.Users == nil {
+		respRoom.Users = make([]*V1User, 0)
+	}
+
+	return respRoom, nil
 }
 
 func (a *App) V1GetRoom(w http.ResponseWriter, r *http.Request) {
@@ -344,8 +374,11 @@ func (a *App) V1UpdateSession(w http.ResponseWriter, r *http.Request) {
 	a.requestContext(r).Params = data
 
 	if err := a.sessionManager.UpsertSession(r.Context(), data); err != nil {
+		var bErr *UserBanError
 		var pErr *ProtocolError
-		if errors.As(err, &pErr) {
+		if errors.As(err, &bErr) {
+			httputil.NewBadRequestError(err, bErr.Error()).Abort(w, r)
+		} else if errors.As(err, &pErr) {
 			httputil.NewBadRequestError(err, "protocol error").Abort(w, r)
 		} else {
 			httputil.NewInternalError(err).Abort(w, r)
@@ -506,6 +539,12 @@ func (a *App) V1HandleEvent(w http.ResponseWriter, r *http.Request) {
 	rCtx := a.requestContext(r)
 	rCtx.Params = body
 
+	// gateways may group events into json arrays
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		a.handleEventsBatch(w, r, trimmed)
+		return
+	}
+
 	event, err := janus.ParseEvent(body)
 	if err != nil {
 		httputil.NewBadRequestError(err, "error parsing request body").Abort(w, r)
@@ -513,7 +552,12 @@ func (a *App) V1HandleEvent(w http.ResponseWriter, r *http.Request) {
 	}
 	rCtx.Params = event
 
-	if err := a.sessionManager.HandleEvent(r.Context(), event); err != nil {
+	result, err := a.eventDispatcher.Dispatch(r.Context(), eventKey(body), event)
+	if err != nil {
+		httputil.NewHttpError(http.StatusServiceUnavailable, err, "event not handled").Abort(w, r)
+		return
+	}
+	if err := <-result; err != nil {
 		httputil.NewInternalError(err).Abort(w, r)
 		return
 	}
@@ -521,6 +565,67 @@ func (a *App) V1HandleEvent(w http.ResponseWriter, r *http.Request) {
 	httputil.RespondSuccess(w)
 }
 
+// handleEventsBatch handles a json array of gateway events.
+// Events are handled in order per gateway session. A failure of one doesn't fail the others.
+// If the batch can't be queued in full (shutting down or the request is gone) we answer 503 for the whole batch.
+func (a *App) handleEventsBatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		httputil.NewBadRequestError(err, "error parsing request body").Abort(w, r)
+		return
+	}
+
+	results := make([]<-chan error, len(raws))
+	resp := V1EventsBatchResult{
+		Total:  len(raws),
+		Failed: make([]*V1EventFailure, 0),
+	}
+	var dispatchErr error
+	for i, raw := range raws {
+		event, err := janus.ParseEvent(raw)
+		if err != nil {
+			resp.Failed = append(resp.Failed, &V1EventFailure{Index: i, Error: fmt.Sprintf("parse: %s", err.Error())})
+			continue
+		}
+		// stop at the first event we couldn't queue so later events of the same session aren't handled without it
+		if results[i], dispatchErr = a.eventDispatcher.Dispatch(r.Context(), eventKey(raw), event); dispatchErr != nil {
+			break
+		}
+	}
+
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		if err := <-result; err != nil {
+			log.Ctx(r.Context()).Error().Err(err).Int("index", i).RawJSON("event", raws[i]).Msg("handle gateway event")
+			resp.Failed = append(resp.Failed, &V1EventFailure{Index: i, Error: err.Error()})
+		}
+	}
+
+	if dispatchErr != nil {
+		httputil.NewHttpError(http.StatusServiceUnavailable, dispatchErr, "events batch not handled in full").Abort(w, r)
+		return
+	}
+
+	sort.Slice(resp.Failed, func(i, j int) bool {
+		return resp.Failed[i].Index < resp.Failed[j].Index
+	})
+	resp.Processed = resp.Total - len(resp.Failed)
+
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+// eventKey identifies the gateway session a raw gateway event belongs to
+func eventKey(raw []byte) string {
+	var base struct {
+		Emitter string `json:"emitter"`
+		Session uint64 `json:"session_id"`
+	}
+	_ = json.Unmarshal(raw, &base)
+	return fmt.Sprintf("%s/%d", base.Emitter, base.Session)
+}
+
 func (a *App) V1HandleProtocol(w http.ResponseWriter, r *http.Request) {
 	body, err := ioutil.ReadAll(r.Body)
 	if err != nil {
@@ -539,8 +644,11 @@ func (a *App) V1HandleProtocol(w http.ResponseWriter, r *http.Request) {
 	rCtx.Params = msg
 
 	if err := a.sessionManager.HandleProtocol(r.Context(), msg); err != nil {
+		var bErr *UserBanError
 		var pErr *ProtocolError
-		if errors.As(err, &pErr) {
+		if errors.As(err, &bErr) {
+			httputil.NewBadRequestError(err, bErr.Error()).Abort(w, r)
+		} else if errors.As(err, &pErr) {
 			httputil.NewBadRequestError(err, "protocol error").Abort(w, r)
 		} else {
 			httputil.NewInternalError(err).Abort(w, r)
@@ -3,11 +3,16 @@ package api
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/rs/zerolog/log"
+
 	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/domain"
 	"github.com/Bnei-Baruch/gxydb-api/pkg/httputil"
 )
 
@@ -18,9 +23,12 @@ func (a *App) V2GetConfig(w http.ResponseWriter, r *http.Request) {
 		DynamicConfig: make(map[string]string),
 	}
 
+	// unhealthy gateways are left out, unless there are no healthy gateways of that type.
+	// In which case they're all in, flagged, so clients have something to try.
+	unhealthy := make(map[string][]*V2Gateway)
 	gateways := a.cache.gateways.Values()
 	for _, gateway := range gateways {
-		if gateway.Disabled || gateway.RemovedAt.Valid {
+		if gateway.Disabled || gateway.Draining || gateway.RemovedAt.Valid {
 			continue
 		}
 
@@ -32,18 +40,47 @@ func (a *App) V2GetConfig(w http.ResponseWriter, r *http.Request) {
 			Token: token,
 		}
 
+		if !domain.GatewayHealthRegistry.IsHealthy(gateway) {
+			respGateway.Unhealthy = true
+			unhealthy[gateway.Type] = append(unhealthy[gateway.Type], respGateway)
+			continue
+		}
+
 		if cfg.Gateways[gateway.Type] == nil {
 			cfg.Gateways[gateway.Type] = make(map[string]*V2Gateway)
 		}
 		cfg.Gateways[gateway.Type][gateway.Name] = respGateway
 	}
 
+	for gType, respGateways := range unhealthy {
+		if cfg.Gateways[gType] != nil {
+			continue
+		}
+		cfg.Gateways[gType] = make(map[string]*V2Gateway)
+		for _, respGateway := range respGateways {
+			cfg.Gateways[gType][respGateway.Name] = respGateway
+		}
+	}
+
 	kvs := a.cache.dynamicConfig.Values()
 	for _, kv := range kvs {
 		cfg.DynamicConfig[kv.Key] = kv.Value
 	}
 	cfg.LastModified = a.cache.dynamicConfig.LastModified()
 
+	mountpoints := a.cache.mountpoints.Values()
+	cfg.Mountpoints = make([]*V2Mountpoint, len(mountpoints))
+	for i, mountpoint := range mountpoints {
+		cfg.Mountpoints[i] = &V2Mountpoint{
+			ID:          mountpoint.GatewayUID,
+			Name:        mountpoint.Name,
+			Description: mountpoint.Description.String,
+			Audio:       mountpoint.Audio,
+			Video:       mountpoint.Video,
+			Data:        mountpoint.Data,
+		}
+	}
+
 	httputil.RespondWithJSON(w, http.StatusOK, cfg)
 }
 
@@ -62,6 +99,81 @@ func (a *App) V2GetRoomsStatistics(w http.ResponseWriter, r *http.Request) {
 	httputil.RespondWithJSON(w, http.StatusOK, data)
 }
 
+const sseKeepAliveInterval = 30 * time.Second
+
+// V2RoomsStream is a server-sent events stream of room events.
+// New clients first get a snapshot of all active rooms followed by incremental events.
+// Reconnecting clients sending a Last-Event-ID header get the events they've missed, if we still have them,
+// otherwise they get a new snapshot.
+func (a *App) V2RoomsStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httputil.NewInternalError(errors.New("streaming is not supported")).Abort(w, r)
+		return
+	}
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+
+	ch, missed, lastID, resumed := a.roomsStream.Subscribe(lastEventID)
+	defer a.roomsStream.Unsubscribe(ch)
+
+	var snapshot []*V1Room
+	if !resumed {
+		var err error
+		snapshot, err = a.activeV1Rooms()
+		if err != nil {
+			httputil.NewInternalError(err).Abort(w, r)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if resumed {
+		for _, event := range missed {
+			writeSSE(w, event.ID, event.Type, event)
+		}
+	} else {
+		writeSSE(w, lastID, "snapshot", snapshot)
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return // we're too slow or shutting down, client should reconnect
+			}
+			writeSSE(w, event.ID, event.Type, event)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeSSE(w http.ResponseWriter, id, event string, data interface{}) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		log.Error().Err(err).Msg("writeSSE json.Marshal")
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, event, b)
+}
+
 func (a *App) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
 	defer cancel()
@@ -25,11 +25,18 @@ type App struct {
 	DB                     common.DBInterface
 	cache                  *AppCache
 	sessionManager         SessionManager
+	eventDispatcher        *EventDispatcher
+	sessionReconciler      *SessionReconciler
+	attendanceRollup       *AttendanceRollup
+	roomsStream            *RoomsStream
 	serviceProtocolHandler ServiceProtocolHandler
 	gatewayTokensManager   *domain.GatewayTokensManager
 	roomsStatisticsManager *domain.RoomStatisticsManager
+	userDataManager        *domain.UserDataManager
+	roomsSyncer            *domain.RoomsSyncer
 	periodicStatsCollector *instrumentation.PeriodicCollector
 	mqttListener           *MQTTListener
+	roomAssignments        *roomAssignments
 }
 
 func (a *App) initOidc(issuerUrls []string) middleware.OIDCTokenVerifier {
@@ -68,14 +75,17 @@ func (a *App) InitializeWithDeps(db common.DBInterface, tokenVerifier middleware
 	a.initSessionManagement()
 	a.initGatewayTokensMonitoring()
 	a.initRoomsStatistics()
+	a.initUserData()
+	a.initRoomsSync()
 	a.initServiceProtocolHandler()
 	a.initMQTT()
 	a.initInstrumentation()
 
 	// this is declared here to abstract away the cache from auth middleware
+	// removed or disabled gateways can't authenticate
 	gatewayPwd := func(name string) (string, bool) {
 		g, ok := a.cache.gateways.ByName(name)
-		if ok {
+		if ok && !g.RemovedAt.Valid && !g.Disabled {
 			return g.EventsPassword, true
 		}
 		return "", false
@@ -140,7 +150,12 @@ func (a *App) Shutdown() {
 	if a.mqttListener != nil {
 		a.mqttListener.Close()
 	}
+	a.eventDispatcher.Close()
 	a.sessionManager.Close()
+	a.sessionReconciler.Close()
+	a.attendanceRollup.Close()
+	a.roomsSyncer.Close()
+	a.roomsStream.Close()
 	a.cache.Close()
 	if err := a.DB.Close(); err != nil {
 		log.Error().Err(err).Msg("DB.close")
@@ -191,17 +206,62 @@ func (a *App) initRoutes() {
 
 	// api v2 (next)
 	a.Router.HandleFunc("/v2/config", a.V2GetConfig).Methods("GET")
+	a.Router.HandleFunc("/v2/assign", a.V2Assign).Methods("GET")
+	a.Router.HandleFunc("/v2/rooms/stream", a.V2RoomsStream).Methods("GET")
+	a.Router.HandleFunc("/v2/rooms/{id}/questions", a.V2ListRoomQuestions).Methods("GET")
+	a.Router.HandleFunc("/v2/questions", a.V2ListQuestions).Methods("GET")
+	a.Router.HandleFunc("/v2/questions/{id}/answer", a.V2AnswerQuestion).Methods("POST")
+	a.Router.HandleFunc("/v2/questions/{id}/dismiss", a.V2DismissQuestion).Methods("POST")
 	a.Router.HandleFunc("/v2/rooms_statistics", a.V2GetRoomsStatistics).Methods("GET") // Here due to more open permissions. otherwise might be under /admin/
 
 	// admin
 	a.Router.HandleFunc("/admin/gateways", a.AdminListGateways).Methods("GET")
+	a.Router.HandleFunc("/admin/gateways", a.AdminCreateGateway).Methods("POST")
+	a.Router.HandleFunc("/admin/gateways/draining", a.AdminListDrainingGateways).Methods("GET")
+	a.Router.HandleFunc("/admin/gateways/{id}", a.AdminGetGateway).Methods("GET")
+	a.Router.HandleFunc("/admin/gateways/{id}", a.AdminUpdateGateway).Methods("PUT")
+	a.Router.HandleFunc("/admin/gateways/{id}", a.AdminDeleteGateway).Methods("DELETE")
+	a.Router.HandleFunc("/admin/gateways/{id}/drain", a.AdminDrainGateway).Methods("POST")
+	a.Router.HandleFunc("/admin/gateways/{id}/drain", a.AdminUndrainGateway).Methods("DELETE")
+	a.Router.HandleFunc("/admin/gateways/{gateway_id}/info", a.AdminGatewayInfo).Methods("GET")
+	a.Router.HandleFunc("/admin/gateways/{gateway_id}/sessions", a.AdminGatewaySessions).Methods("GET")
+	a.Router.HandleFunc("/admin/gateways/{gateway_id}/sessions/{session_id}/handles", a.AdminGatewaySessionHandles).Methods("GET")
 	a.Router.HandleFunc("/admin/gateways/{gateway_id}/sessions/{session_id}/handles/{handle_id}/info", a.AdminGatewaysHandleInfo).Methods("GET")
 	a.Router.HandleFunc("/admin/rooms", a.AdminListRooms).Methods("GET")
 	a.Router.HandleFunc("/admin/rooms", a.AdminCreateRoom).Methods("POST")
+	a.Router.HandleFunc("/admin/rooms/sync", a.AdminSyncRooms).Methods("POST")
 	a.Router.HandleFunc("/admin/rooms/{id}", a.AdminGetRoom).Methods("GET")
 	a.Router.HandleFunc("/admin/rooms/{id}", a.AdminUpdateRoom).Methods("PUT")
 	a.Router.HandleFunc("/admin/rooms/{id}", a.AdminDeleteRoom).Methods("DELETE")
 	a.Router.HandleFunc("/admin/rooms_statistics", a.AdminDeleteRoomsStatistics).Methods("DELETE")
+	a.Router.HandleFunc("/admin/room_profiles", a.AdminListRoomProfiles).Methods("GET")
+	a.Router.HandleFunc("/admin/room_profiles", a.AdminCreateRoomProfile).Methods("POST")
+	a.Router.HandleFunc("/admin/room_profiles/{id}", a.AdminGetRoomProfile).Methods("GET")
+	a.Router.HandleFunc("/admin/room_profiles/{id}", a.AdminUpdateRoomProfile).Methods("PUT")
+	a.Router.HandleFunc("/admin/room_profiles/{id}", a.AdminDeleteRoomProfile).Methods("DELETE")
+	a.Router.HandleFunc("/admin/mountpoints", a.AdminListMountpoints).Methods("GET")
+	a.Router.HandleFunc("/admin/mountpoints", a.AdminCreateMountpoint).Methods("POST")
+	a.Router.HandleFunc("/admin/mountpoints/{id}", a.AdminGetMountpoint).Methods("GET")
+	a.Router.HandleFunc("/admin/mountpoints/{id}", a.AdminUpdateMountpoint).Methods("PUT")
+	a.Router.HandleFunc("/admin/mountpoints/{id}", a.AdminDeleteMountpoint).Methods("DELETE")
+	a.Router.HandleFunc("/admin/reconcile", a.AdminGetReconcileReport).Methods("GET")
+	a.Router.HandleFunc("/admin/reconcile", a.AdminReconcile).Methods("POST")
+	a.Router.HandleFunc("/admin/sessions", a.AdminListSessions).Methods("GET")
+	a.Router.HandleFunc("/admin/sessions/{id}/kick", a.AdminKickSession).Methods("POST")
+	a.Router.HandleFunc("/admin/sessions/{id}/events", a.AdminListSessionEvents).Methods("GET")
+	a.Router.HandleFunc("/admin/bans", a.AdminListBans).Methods("GET")
+	a.Router.HandleFunc("/admin/bans", a.AdminCreateBan).Methods("POST")
+	a.Router.HandleFunc("/admin/bans/{id}", a.AdminLiftBan).Methods("DELETE")
+	a.Router.HandleFunc("/admin/users", a.AdminListUsers).Methods("GET")
+	a.Router.HandleFunc("/admin/users/{id}", a.AdminGetUser).Methods("GET")
+	a.Router.HandleFunc("/admin/users/{id}", a.AdminUpdateUser).Methods("PUT")
+	a.Router.HandleFunc("/admin/users/{id}", a.AdminDeleteUser).Methods("DELETE")
+	a.Router.HandleFunc("/admin/users/{accounts_id}/export", a.AdminExportUser).Methods("GET")
+	a.Router.HandleFunc("/admin/users/{accounts_id}/erase", a.AdminEraseUser).Methods("POST")
+	a.Router.HandleFunc("/admin/users/{accounts_id}/events", a.AdminListUserEvents).Methods("GET")
+	a.Router.HandleFunc("/admin/users/{accounts_id}/kick", a.AdminKickUser).Methods("POST")
+	a.Router.HandleFunc("/admin/reports/attendance", a.AdminAttendanceReport).Methods("GET")
+	a.Router.HandleFunc("/admin/reports/attendance/rollup", a.AdminRollupAttendance).Methods("POST")
 	a.Router.HandleFunc("/admin/dynamic_config", a.AdminListDynamicConfigs).Methods("GET")
 	a.Router.HandleFunc("/admin/dynamic_config", a.AdminCreateDynamicConfig).Methods("POST")
 	a.Router.HandleFunc("/admin/dynamic_config/{id}", a.AdminGetDynamicConfig).Methods("GET")
@@ -225,11 +285,22 @@ func (a *App) initCache() {
 	if err := a.cache.Init(a.DB); err != nil {
 		log.Fatal().Err(err).Msg("initialize app cache")
 	}
+	a.roomAssignments = newRoomAssignments()
 }
 
 func (a *App) initSessionManagement() {
+	a.roomsStream = NewRoomsStream(a.DB)
+	a.roomsStream.Start()
 	a.sessionManager = NewV1SessionManager(a.DB, a.cache)
+	a.sessionManager.AddObserver(a.roomsStream)
 	a.sessionManager.Start()
+	a.eventDispatcher = NewEventDispatcher(a.sessionManager, common.Config.EventWorkers)
+	a.eventDispatcher.Start()
+	a.sessionReconciler = NewSessionReconciler(a.DB, a.cache)
+	a.sessionReconciler.AddObserver(a.roomsStream)
+	a.sessionReconciler.Start()
+	a.attendanceRollup = NewAttendanceRollup(a.DB)
+	a.attendanceRollup.Start()
 }
 
 func (a *App) initServiceProtocolHandler() {
@@ -248,19 +319,29 @@ func (a *App) initRoomsStatistics() {
 	a.roomsStatisticsManager = domain.NewRoomStatisticsManager(a.DB)
 }
 
+func (a *App) initUserData() {
+	a.userDataManager = domain.NewUserDataManager(a.DB)
+}
+
+func (a *App) initRoomsSync() {
+	a.roomsSyncer = domain.NewRoomsSyncer(a.DB)
+	a.roomsSyncer.Start()
+}
+
 func (a *App) initInstrumentation() {
 	instrumentation.Stats.Init()
-	if common.Config.CollectPeriodicStats {
-		a.periodicStatsCollector = instrumentation.NewPeriodicCollector(a.DB)
-		a.periodicStatsCollector.Start()
-	}
+	// always started, gateways health tracking depends on it
+	a.periodicStatsCollector = instrumentation.NewPeriodicCollector(a.DB, common.Config.CollectPeriodicStats)
+	a.periodicStatsCollector.Start()
 }
 
 func (a *App) initMQTT() {
 	if common.Config.MQTTBrokerUrl != "" {
-		a.mqttListener = NewMQTTListener(a.cache, a.serviceProtocolHandler)
+		a.mqttListener = NewMQTTListener(a.DB, a.cache, a.serviceProtocolHandler, a.v1RoomState)
 		if err := a.mqttListener.Start(); err != nil {
 			log.Fatal().Err(err).Msg("initialize mqtt listener")
 		}
+		a.sessionManager.AddObserver(a.mqttListener)
+		a.sessionReconciler.AddObserver(a.mqttListener)
 	}
 }
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/domain"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/httputil"
+)
+
+const (
+	AssignReasonRoom     = "room"     // room already has users on this gateway
+	AssignReasonDefault  = "default"  // room's default gateway has spare capacity
+	AssignReasonLoad     = "load"     // least loaded gateway with spare capacity
+	AssignReasonOverflow = "overflow" // all gateways are at capacity, least loaded one
+)
+
+type V2Assignment struct {
+	Gateway *V2Gateway `json:"gateway"`
+	Reason  string     `json:"reason"`
+}
+
+// gatewayLoad is the number of active sessions on a gateway
+type gatewayLoad struct {
+	total  int
+	inRoom int
+}
+
+// V2Assign returns the rooms gateway a user joining the given room should connect to.
+func (a *App) V2Assign(w http.ResponseWriter, r *http.Request) {
+	room, ok := a.cache.rooms.ByGatewayUID(r.URL.Query().Get("room"))
+	if !ok || room.Disabled || room.RemovedAt.Valid {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	gateway, reason, err := a.assignRoomGateway(room)
+	if err != nil {
+		httputil.NewInternalError(err).Abort(w, r)
+		return
+	}
+	if gateway == nil {
+		httputil.NewHttpError(http.StatusServiceUnavailable, nil, "no gateway available").Abort(w, r)
+		return
+	}
+
+	token, _ := a.cache.gatewayTokens.ByID(gateway.ID)
+	httputil.RespondWithJSON(w, http.StatusOK, V2Assignment{
+		Gateway: &V2Gateway{
+			Name:  gateway.Name,
+			URL:   gateway.URL,
+			Type:  gateway.Type,
+			Token: token,
+		},
+		Reason: reason,
+	})
+}
+
+// roomAssignmentTTL is how long an assignment counts as a pending session in the room.
+// Users joining the room meanwhile are assigned the same gateway, even before their sessions show up.
+const roomAssignmentTTL = 30 * time.Second
+
+type roomAssignment struct {
+	gatewayID int64
+	at        time.Time
+}
+
+// roomAssignments keeps the last gateway assigned to each room. It's kept in memory only,
+// the room's configured default gateway is never changed by assignments.
+type roomAssignments struct {
+	lock   sync.Mutex
+	byRoom map[int64]*roomAssignment
+}
+
+func newRoomAssignments() *roomAssignments {
+	return &roomAssignments{
+		byRoom: make(map[int64]*roomAssignment),
+	}
+}
+
+// assignRoomGateway assigns a gateway to the room so users joining the room concurrently,
+// or while its gateway is down, all end up on the same gateway.
+// A recent assignment of the room is counted as a session in the room on the assigned gateway.
+func (a *App) assignRoomGateway(room *models.Room) (*models.Gateway, string, error) {
+	loads, err := gatewayLoads(a.DB, room)
+	if err != nil {
+		return nil, "", err
+	}
+
+	a.roomAssignments.lock.Lock()
+	defer a.roomAssignments.lock.Unlock()
+
+	if last, ok := a.roomAssignments.byRoom[room.ID]; ok {
+		if time.Since(last.at) < roomAssignmentTTL {
+			load, ok := loads[last.gatewayID]
+			if !ok {
+				load = new(gatewayLoad)
+				loads[last.gatewayID] = load
+			}
+			load.total++
+			load.inRoom++
+		} else {
+			delete(a.roomAssignments.byRoom, room.ID)
+		}
+	}
+
+	gateway, reason := assignGateway(room, a.cache.gateways.Values(), loads)
+	if gateway != nil {
+		a.roomAssignments.byRoom[room.ID] = &roomAssignment{gatewayID: gateway.ID, at: time.Now()}
+	}
+
+	return gateway, reason, nil
+}
+
+// gatewayLoads counts active sessions per gateway, in total and in the given room
+func gatewayLoads(exec boil.Executor, room *models.Room) (map[int64]*gatewayLoad, error) {
+	rows, err := models.Sessions(
+		qm.Select("gateway_id", "count(*)", fmt.Sprintf("count(*) filter (where room_id = %d)", room.ID)),
+		models.SessionWhere.RemovedAt.IsNull(),
+		models.SessionWhere.GatewayID.IsNotNull(),
+		qm.GroupBy("gateway_id"),
+	).Query.Query(exec)
+	if err != nil {
+		return nil, pkgerr.WithStack(err)
+	}
+	defer rows.Close()
+
+	loads := make(map[int64]*gatewayLoad)
+	for rows.Next() {
+		var gatewayID int64
+		load := new(gatewayLoad)
+		if err := rows.Scan(&gatewayID, &load.total, &load.inRoom); err != nil {
+			return nil, pkgerr.WithStack(err)
+		}
+		loads[gatewayID] = load
+	}
+	if err := rows.Err(); err != nil {
+		return nil, pkgerr.WithStack(err)
+	}
+
+	return loads, nil
+}
+
+// assignGateway picks a gateway for a user joining the room.
+// Only enabled, healthy and not draining rooms gateways are considered.
+// Users of a room must share a gateway to see each other, so a room with active sessions
+// sticks to the gateway most of them are on.
+// Otherwise the room's default gateway is picked if it has spare capacity (max_sessions) and is in the room's region.
+// Failing that we pick the least loaded gateway with spare capacity, preferring gateways in the room's region.
+// If every gateway is at capacity the least loaded one is picked anyway.
+func assignGateway(room *models.Room, gateways []*models.Gateway, loads map[int64]*gatewayLoad) (*models.Gateway, string) {
+	load := func(g *models.Gateway) *gatewayLoad {
+		if l, ok := loads[g.ID]; ok {
+			return l
+		}
+		return new(gatewayLoad)
+	}
+
+	candidates := make([]*models.Gateway, 0)
+	for _, gateway := range gateways {
+		if gateway.Type != common.GatewayTypeRooms ||
+			gateway.Disabled ||
+			gateway.Draining ||
+			gateway.RemovedAt.Valid ||
+			!domain.GatewayHealthRegistry.IsHealthy(gateway) {
+			continue
+		}
+		candidates = append(candidates, gateway)
+	}
+	if len(candidates) == 0 {
+		return nil, ""
+	}
+
+	// stable order: default gateway first, then by name
+	sort.Slice(candidates, func(i, j int) bool {
+		if (candidates[i].ID == room.DefaultGatewayID) != (candidates[j].ID == room.DefaultGatewayID) {
+			return candidates[i].ID == room.DefaultGatewayID
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	var sticky *models.Gateway
+	for _, gateway := range candidates {
+		if l := load(gateway); l.inRoom > 0 && (sticky == nil || l.inRoom > load(sticky).inRoom) {
+			sticky = gateway
+		}
+	}
+	if sticky != nil {
+		return sticky, AssignReasonRoom
+	}
+
+	available := make([]*models.Gateway, 0, len(candidates))
+	for _, gateway := range candidates {
+		if !gateway.MaxSessions.Valid || load(gateway).total < gateway.MaxSessions.Int {
+			available = append(available, gateway)
+		}
+	}
+	reason := AssignReasonLoad
+	if len(available) == 0 {
+		available = candidates
+		reason = AssignReasonOverflow
+	}
+
+	if room.Region.Valid {
+		inRegion := make([]*models.Gateway, 0, len(available))
+		for _, gateway := range available {
+			if gateway.Region == room.Region {
+				inRegion = append(inRegion, gateway)
+			}
+		}
+		if len(inRegion) > 0 {
+			available = inRegion
+		}
+	}
+
+	if reason == AssignReasonLoad && available[0].ID == room.DefaultGatewayID {
+		return available[0], AssignReasonDefault
+	}
+
+	best := available[0]
+	for _, gateway := range available[1:] {
+		if load(gateway).total < load(best).total {
+			best = gateway
+		}
+	}
+
+	return best, reason
+}
+
+// migrateHints tells users on a draining gateway where their room should move to.
+// Rooms for which there is no other gateway available are skipped.
+func (a *App) migrateHints(gateway *models.Gateway) ([]*V2RoomEvent, error) {
+	sessions, err := models.Sessions(
+		models.SessionWhere.GatewayID.EQ(null.Int64From(gateway.ID)),
+		models.SessionWhere.RemovedAt.IsNull(),
+		models.SessionWhere.RoomID.IsNotNull(),
+		qm.Load(models.SessionRels.User),
+	).All(a.DB)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch sessions")
+	}
+
+	rooms := make(map[int64]*models.Room)
+	for _, room := range a.cache.rooms.Values() {
+		rooms[room.ID] = room
+	}
+	targets := make(map[int64]*models.Gateway)
+
+	hints := make([]*V2RoomEvent, 0, len(sessions))
+	for _, session := range sessions {
+		room, ok := rooms[session.RoomID.Int64]
+		if !ok {
+			continue
+		}
+
+		target, ok := targets[room.ID]
+		if !ok {
+			var err error
+			target, _, err = a.assignRoomGateway(room)
+			if err != nil {
+				return nil, err
+			}
+			if target == nil {
+				log.Warn().Str("gateway", gateway.Name).Int("room", room.GatewayUID).Msg("no gateway to migrate room to")
+			}
+			targets[room.ID] = target
+		}
+		if target == nil {
+			continue
+		}
+
+		hint := &V2RoomEvent{
+			Type:    RoomEventMigrate,
+			Room:    room.GatewayUID,
+			Display: session.Display.String,
+			Gateway: target.Name,
+			roomID:  room.ID,
+		}
+		if session.R.User != nil {
+			hint.User = session.R.User.AccountsID
+		}
+		hints = append(hints, hint)
+	}
+
+	return hints, nil
+}
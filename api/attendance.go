@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/sqlutil"
+)
+
+// AttendanceRollup periodically aggregates closed sessions into daily_attendance.
+// Every run recomputes each day (UTC) touched by sessions closed since the previous run,
+// from the first day of the earliest such session up to today.
+type AttendanceRollup struct {
+	ticker *time.Ticker
+	db     common.DBInterface
+}
+
+func NewAttendanceRollup(db common.DBInterface) *AttendanceRollup {
+	return &AttendanceRollup{db: db}
+}
+
+func (ar *AttendanceRollup) Start() {
+	if common.Config.AttendanceInterval <= 0 {
+		return
+	}
+
+	log.Info().Msg("periodically rolling up attendance")
+	ar.ticker = time.NewTicker(common.Config.AttendanceInterval)
+	go ar.run()
+}
+
+func (ar *AttendanceRollup) Close() {
+	if ar.ticker != nil {
+		ar.ticker.Stop()
+	}
+}
+
+func (ar *AttendanceRollup) run() {
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	for range ar.ticker.C {
+		now := time.Now().UTC()
+		if err := ar.RollupSince(since); err != nil {
+			log.Error().Err(err).Msg("AttendanceRollup")
+			continue
+		}
+		since = now
+	}
+}
+
+// RollupSince recomputes every day from the day of since, or the first day of an earlier session
+// closed since then, up to today.
+func (ar *AttendanceRollup) RollupSince(since time.Time) error {
+	var first null.Time
+	if err := queries.Raw("select min(created_at) from sessions where removed_at >= $1", since).
+		QueryRow(ar.db).Scan(&first); err != nil {
+		return pkgerr.Wrap(err, "db fetch first closed session")
+	}
+
+	from := since.UTC().Truncate(24 * time.Hour)
+	if first.Valid && first.Time.Before(from) {
+		from = first.Time.UTC().Truncate(24 * time.Hour)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	var err error
+	for day := from; !day.After(today); day = day.AddDate(0, 0, 1) {
+		if dErr := ar.Rollup(day); dErr != nil {
+			log.Error().Err(dErr).Msgf("AttendanceRollup %s", day.Format("2006-01-02"))
+			err = dErr
+		}
+	}
+
+	return err
+}
+
+// Rollup (re)computes the attendance of a single day from sessions closed during or after it.
+// Sessions spanning midnight are split between days. Overlapping sessions of a user in a room,
+// like those of several tabs, are merged so their time is counted once.
+// Rows of the day which are no longer backed by sessions are removed.
+func (ar *AttendanceRollup) Rollup(day time.Time) error {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	var affected int64
+	err := sqlutil.InTx(context.Background(), ar.db, func(tx *sql.Tx) error {
+		if _, err := queries.Raw("delete from daily_attendance where date = $1::date", start).Exec(tx); err != nil {
+			return pkgerr.Wrap(err, "db delete daily_attendance")
+		}
+
+		res, err := queries.Raw(`with spans as (
+    select s.id,
+           s.user_id,
+           s.room_id,
+           greatest(s.created_at, $1) as started,
+           least(s.removed_at, $2)    as ended
+    from sessions s
+    where s.removed_at is not null
+      and s.created_at < $2
+      and s.removed_at > $1
+),
+     marked as (
+         select *,
+                case
+                    when started <= max(ended) over (partition by user_id, room_id order by started, ended, id
+                        rows between unbounded preceding and 1 preceding) then 0
+                    else 1 end as new_island
+         from spans
+     ),
+     islands as (
+         select *,
+                sum(new_island) over (partition by user_id, room_id order by started, ended, id
+                    rows between unbounded preceding and current row) as island
+         from marked
+     ),
+     merged as (
+         select user_id, room_id, min(started) as started, max(ended) as ended, count(*) as sessions
+         from islands
+         group by user_id, room_id, island
+     )
+insert
+into daily_attendance (date, user_id, room_id, region, first_seen, last_seen, total_minutes, sessions, updated_at)
+select $1::date,
+       m.user_id,
+       m.room_id,
+       r.region,
+       min(m.started),
+       max(m.ended),
+       (sum(extract(epoch from m.ended - m.started)) / 60)::integer,
+       sum(m.sessions)::integer,
+       now()
+from merged m
+         inner join rooms r on m.room_id = r.id
+group by m.user_id, m.room_id, r.region`,
+			start, end).Exec(tx)
+		if err != nil {
+			return pkgerr.Wrap(err, "db insert daily_attendance")
+		}
+		affected, _ = res.RowsAffected()
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msgf("AttendanceRollup %s: %d rows", start.Format("2006-01-02"), affected)
+
+	return nil
+}
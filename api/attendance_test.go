@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+func (s *ApiTestSuite) TestAttendance_Rollup() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	room.Region = null.StringFrom("europe")
+	_, err := room.Update(s.DB, boil.Infer())
+	s.Require().NoError(err)
+	user := s.CreateUser()
+
+	day := time.Date(2020, 12, 10, 0, 0, 0, 0, time.UTC)
+	spans := []struct{ from, to time.Time }{
+		{day.Add(-30 * time.Minute), day.Add(30 * time.Minute)}, // crosses midnight
+		{day.Add(10 * time.Hour), day.Add(11 * time.Hour)},
+		{day.Add(10*time.Hour + 30*time.Minute), day.Add(11*time.Hour + 30*time.Minute)}, // overlaps the previous one
+	}
+	for _, span := range spans {
+		session := s.CreateSession(user, gateway, room)
+		_, err := models.Sessions(models.SessionWhere.ID.EQ(session.ID)).UpdateAll(s.DB, models.M{
+			"created_at": span.from,
+			"removed_at": span.to,
+		})
+		s.Require().NoError(err)
+	}
+	s.CreateSession(user, gateway, room) // open sessions are not accounted for
+
+	// rows no longer backed by sessions are removed
+	stale := &models.DailyAttendance{
+		Date:         day,
+		UserID:       s.CreateUser().ID,
+		RoomID:       room.ID,
+		FirstSeen:    day,
+		LastSeen:     day.Add(time.Hour),
+		TotalMinutes: 60,
+		Sessions:     1,
+	}
+	s.Require().NoError(stale.Insert(s.DB, boil.Infer()))
+
+	rollup := NewAttendanceRollup(s.DB)
+	s.Require().NoError(rollup.Rollup(day))
+	s.Require().NoError(rollup.Rollup(day)) // idempotent
+
+	attendance, err := models.DailyAttendances().All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(attendance, 1, "daily attendance")
+	s.Equal(user.ID, attendance[0].UserID, "user_id")
+	s.Equal(room.ID, attendance[0].RoomID, "room_id")
+	s.Equal("europe", attendance[0].Region.String, "region")
+	s.Equal(120, attendance[0].TotalMinutes, "total_minutes")
+	s.Equal(3, attendance[0].Sessions, "sessions")
+	s.True(day.Equal(attendance[0].FirstSeen), "first_seen")
+}
+
+func (s *ApiTestSuite) TestAttendance_RollupSince() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+
+	// a long session closed just now is accounted for on every day it spans
+	now := time.Now().UTC()
+	today := now.Truncate(24 * time.Hour)
+	session := s.CreateSession(user, gateway, room)
+	_, err := models.Sessions(models.SessionWhere.ID.EQ(session.ID)).UpdateAll(s.DB, models.M{
+		"created_at": today.AddDate(0, 0, -3).Add(12 * time.Hour),
+		"removed_at": now,
+	})
+	s.Require().NoError(err)
+
+	s.Require().NoError(NewAttendanceRollup(s.DB).RollupSince(now.Add(-time.Minute)))
+
+	attendance, err := models.DailyAttendances(qm.OrderBy(models.DailyAttendanceColumns.Date)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(attendance, 4, "daily attendance")
+	for i, da := range attendance {
+		s.True(today.AddDate(0, 0, i-3).Equal(da.Date.UTC()), "date %d", i)
+	}
+	s.Equal(12*60, attendance[0].TotalMinutes, "first day total_minutes")
+	s.Equal(24*60, attendance[1].TotalMinutes, "full day total_minutes")
+}
+
+func (s *ApiTestSuite) TestAdmin_AttendanceReportForbidden() {
+	req, _ := http.NewRequest("GET", "/admin/reports/attendance", nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+}
+
+func (s *ApiTestSuite) TestAdmin_AttendanceReportBadRequest() {
+	for _, q := range []string{"from=yesterday", "to=2020-13-01", "from=2020-12-10&to=2020-12-09", "room_id=a"} {
+		req, _ := http.NewRequest("GET", fmt.Sprintf("/admin/reports/attendance?%s", q), nil)
+		s.apiAuthP(req, []string{common.RoleAdmin})
+		resp := s.request(req)
+		s.Equal(http.StatusBadRequest, resp.Code, q)
+	}
+}
+
+func (s *ApiTestSuite) TestAdmin_AttendanceReport() {
+	gateway := s.CreateGateway()
+	rooms := []*models.Room{s.CreateRoom(gateway), s.CreateRoom(gateway)}
+	users := []*models.User{s.CreateUser(), s.CreateUser(), s.CreateUser()}
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	day := time.Date(2020, 12, 10, 0, 0, 0, 0, time.UTC)
+	for i, user := range users {
+		da := &models.DailyAttendance{
+			Date:         day,
+			UserID:       user.ID,
+			RoomID:       rooms[i%2].ID,
+			FirstSeen:    day.Add(time.Hour),
+			LastSeen:     day.Add(2 * time.Hour),
+			TotalMinutes: 60,
+			Sessions:     1,
+		}
+		s.Require().NoError(da.Insert(s.DB, boil.Infer()))
+	}
+
+	req, _ := http.NewRequest("GET", "/admin/reports/attendance?from=2020-12-10&to=2020-12-10", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body := s.request200json(req)
+	s.EqualValues(3, body["total"], "total")
+	s.Len(body["data"], 3, "data")
+	summary := body["summary"].(map[string]interface{})
+	s.EqualValues(3, summary["distinct_users"], "distinct_users")
+	s.EqualValues(180, summary["total_minutes"], "total_minutes")
+	s.Len(summary["rooms"], 2, "rooms")
+
+	req, _ = http.NewRequest("GET", fmt.Sprintf("/admin/reports/attendance?from=2020-12-10&to=2020-12-10&room_id=%d", rooms[1].ID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.EqualValues(1, body["total"], "total")
+
+	req, _ = http.NewRequest("GET", "/admin/reports/attendance?from=2020-12-11&to=2020-12-12", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.EqualValues(0, body["total"], "total")
+
+	req, _ = http.NewRequest("GET", "/admin/reports/attendance?from=2020-12-10&to=2020-12-10&format=csv", nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	resp := s.request(req)
+	s.Require().Equal(http.StatusOK, resp.Code)
+	s.Equal("text/csv", resp.Header().Get("Content-Type"))
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	s.Require().NoError(err)
+	s.Require().Len(records, 4, "csv records")
+	s.Equal("accounts_id", records[0][1], "csv header")
+}
@@ -21,7 +21,9 @@ type AppCache struct {
 	gatewayTokens *GatewayTokenCache
 	rooms         *RoomCache
 	users         *UserCache
+	bans          *BanCache
 	dynamicConfig *DynamicConfigCache
+	mountpoints   *MountpointCache
 	ticker        *time.Ticker
 	ticks         int64
 }
@@ -32,7 +34,9 @@ func (c *AppCache) Init(db common.DBInterface) error {
 	c.gatewayTokens = new(GatewayTokenCache)
 	c.rooms = new(RoomCache)
 	c.users = new(UserCache)
+	c.bans = new(BanCache)
 	c.dynamicConfig = new(DynamicConfigCache)
+	c.mountpoints = new(MountpointCache)
 
 	c.ticker = time.NewTicker(time.Second)
 	go func() {
@@ -47,6 +51,9 @@ func (c *AppCache) Init(db common.DBInterface) error {
 				if err := c.dynamicConfig.Reload(c.db); err != nil {
 					log.Error().Err(err).Msg("dynamicConfig.Reload")
 				}
+				if err := c.bans.Reload(c.db); err != nil {
+					log.Error().Err(err).Msg("bans.Reload")
+				}
 			}
 		}
 	}()
@@ -75,10 +82,18 @@ func (c *AppCache) ReloadAll(db common.DBInterface) error {
 		return pkgerr.Wrap(err, "reload users")
 	}
 
+	if err := c.bans.Reload(db); err != nil {
+		return pkgerr.Wrap(err, "reload bans")
+	}
+
 	if err := c.dynamicConfig.Reload(db); err != nil {
 		return pkgerr.Wrap(err, "reload dynamicConfig")
 	}
 
+	if err := c.mountpoints.Reload(db); err != nil {
+		return pkgerr.Wrap(err, "reload mountpoints")
+	}
+
 	return nil
 }
 
@@ -267,6 +282,56 @@ func (c *UserCache) Set(user *models.User) {
 	c.cache.Add(user.AccountsID, user)
 }
 
+func (c *UserCache) Remove(accountsID string) {
+	c.cache.Remove(accountsID)
+}
+
+// BanCache holds the bans in effect (not lifted, not expired) by user ID.
+// Expired bans are ignored on lookup so they lift on time regardless of reloads.
+type BanCache struct {
+	m    map[int64][]*models.UserBan
+	lock sync.RWMutex
+}
+
+func (c *BanCache) Reload(db common.DBInterface) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	bans, err := models.UserBans(
+		models.UserBanWhere.LiftedAt.IsNull(),
+		models.UserBanWhere.Until.GT(time.Now().UTC()),
+	).All(db)
+	if err != nil {
+		return pkgerr.WithStack(err)
+	}
+
+	c.m = make(map[int64][]*models.UserBan, len(bans))
+	for _, ban := range bans {
+		c.m[ban.UserID] = append(c.m[ban.UserID], ban)
+	}
+
+	return nil
+}
+
+// Find returns the ban, with the latest expiry, that keeps the user out of the room.
+// A zero roomID matches only bans from all rooms.
+func (c *BanCache) Find(userID int64, roomID int64) (*models.UserBan, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var found *models.UserBan
+	now := time.Now()
+	for _, ban := range c.m[userID] {
+		if ban.Until.After(now) &&
+			(!ban.RoomID.Valid || ban.RoomID.Int64 == roomID) &&
+			(found == nil || ban.Until.After(found.Until)) {
+			found = ban
+		}
+	}
+
+	return found, found != nil
+}
+
 type DynamicConfigCache struct {
 	m            map[string]*models.DynamicConfig
 	lock         sync.RWMutex
@@ -328,3 +393,34 @@ func (c *DynamicConfigCache) LastModified() time.Time {
 	defer c.lock.RUnlock()
 	return c.lastModified
 }
+
+// MountpointCache holds the enabled mountpoints, ordered by gateway_uid
+type MountpointCache struct {
+	values []*models.Mountpoint
+	lock   sync.RWMutex
+}
+
+func (c *MountpointCache) Reload(db common.DBInterface) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	mountpoints, err := models.Mountpoints(
+		models.MountpointWhere.Disabled.EQ(false),
+		qm.OrderBy(models.MountpointColumns.GatewayUID),
+	).All(db)
+	if err != nil {
+		return pkgerr.WithStack(err)
+	}
+
+	c.values = mountpoints
+	return nil
+}
+
+func (c *MountpointCache) Values() []*models.Mountpoint {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	values := make([]*models.Mountpoint, len(c.values))
+	copy(values, c.values)
+	return values
+}
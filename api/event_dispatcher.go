@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+const eventDispatcherQueue = 100 // pending events per worker
+
+// ErrEventDispatcherClosed is the error of events dispatched after Close
+var ErrEventDispatcherClosed = errors.New("event dispatcher is closed")
+
+type eventJob struct {
+	ctx    context.Context
+	event  interface{}
+	result chan<- error
+}
+
+// EventDispatcher handles gateway events on a bounded pool of workers.
+// Events are routed to workers by their gateway session so events of the same session
+// (and hence the same user) are handled one after the other, in the order they were dispatched.
+type EventDispatcher struct {
+	sessionManager SessionManager
+	workers        []chan *eventJob
+	wg             sync.WaitGroup
+	lock           sync.RWMutex
+	closed         bool
+}
+
+func NewEventDispatcher(sessionManager SessionManager, workers int) *EventDispatcher {
+	return &EventDispatcher{
+		sessionManager: sessionManager,
+		workers:        make([]chan *eventJob, workers),
+	}
+}
+
+func (d *EventDispatcher) Start() {
+	log.Info().Msgf("starting %d gateway event workers", len(d.workers))
+	for i := range d.workers {
+		d.workers[i] = make(chan *eventJob, eventDispatcherQueue)
+		d.wg.Add(1)
+		go d.work(d.workers[i])
+	}
+}
+
+// Close stops accepting events and waits for the queued ones to be handled
+func (d *EventDispatcher) Close() {
+	d.lock.Lock()
+	d.closed = true
+	for _, ch := range d.workers {
+		close(ch)
+	}
+	d.lock.Unlock()
+
+	d.wg.Wait()
+}
+
+func (d *EventDispatcher) work(jobs <-chan *eventJob) {
+	defer d.wg.Done()
+	for job := range jobs {
+		job.result <- d.handle(job)
+	}
+}
+
+func (d *EventDispatcher) handle(job *eventJob) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic handling event: %v", p)
+		}
+	}()
+	return d.sessionManager.HandleEvent(job.ctx, job.event)
+}
+
+// Dispatch queues an event for handling. The result of handling it is sent on the returned channel.
+// key identifies the gateway session of the event.
+// Gateways don't resend events so Dispatch waits for room in a full worker queue rather than dropping the event.
+// An error is returned if the event was not queued: ErrEventDispatcherClosed or the error of ctx if it's done first.
+func (d *EventDispatcher) Dispatch(ctx context.Context, key string, event interface{}) (<-chan error, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	if d.closed {
+		return nil, ErrEventDispatcherClosed
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	result := make(chan error, 1)
+	job := &eventJob{
+		ctx:    ctx,
+		event:  event,
+		result: result,
+	}
+
+	// workers keep draining their queue while Close waits for the lock
+	select {
+	case d.workers[h.Sum32()%uint32(len(d.workers))] <- job:
+		return result, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventDispatcherClosed(t *testing.T) {
+	d := NewEventDispatcher(nil, 2)
+	d.Start()
+	d.Close()
+
+	_, err := d.Dispatch(context.Background(), "key", nil)
+	assert.Equal(t, ErrEventDispatcherClosed, err, "dispatch after close")
+}
+
+type blockingSessionManager struct {
+	SessionManager
+	started chan struct{}
+	release chan struct{}
+}
+
+func (sm *blockingSessionManager) HandleEvent(ctx context.Context, event interface{}) error {
+	sm.started <- struct{}{}
+	<-sm.release
+	return nil
+}
+
+func TestEventDispatcherFullQueue(t *testing.T) {
+	sm := &blockingSessionManager{
+		started: make(chan struct{}, eventDispatcherQueue+2),
+		release: make(chan struct{}),
+	}
+	d := NewEventDispatcher(sm, 1)
+	d.Start()
+
+	results := make([]<-chan error, 0, eventDispatcherQueue+2)
+	result, err := d.Dispatch(context.Background(), "key", nil)
+	assert.NoError(t, err, "dispatch")
+	results = append(results, result)
+	<-sm.started
+	for i := 0; i < eventDispatcherQueue; i++ {
+		result, err := d.Dispatch(context.Background(), "key", nil)
+		assert.NoError(t, err, "dispatch")
+		results = append(results, result)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = d.Dispatch(ctx, "key", nil)
+	assert.Equal(t, context.DeadlineExceeded, err, "dispatch to a full queue until ctx is done")
+
+	queued := make(chan error, 1)
+	go func() {
+		result, err := d.Dispatch(context.Background(), "key", nil)
+		if err == nil {
+			err = <-result
+		}
+		queued <- err
+	}()
+	select {
+	case <-queued:
+		assert.FailNow(t, "dispatch didn't wait for a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(sm.release)
+	assert.NoError(t, <-queued, "waiting event")
+	d.Close()
+	for _, result := range results {
+		assert.NoError(t, <-result, "queued event")
+	}
+
+	_, err = d.Dispatch(context.Background(), "key", nil)
+	assert.Equal(t, ErrEventDispatcherClosed, err, "dispatch after close")
+}
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"sync"
+	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	pkgerr "github.com/pkg/errors"
@@ -10,18 +13,46 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
 )
 
+const (
+	mqttPendingEvents = 10000       // session events buffered while the publisher is behind
+	mqttRetryInterval = time.Second // between attempts to publish a failed batch of session events
+)
+
+// RoomStateFunc returns the current state of a room by its DB id
+type RoomStateFunc func(int64) (*V1Room, error)
+
+// MQTTListener handles service protocol messages and publishes the session events it observes.
+// Room state is published (retained) to galaxy/rooms/{gateway_uid}.
+// User session events are published to galaxy/users/{accounts_id}.
+// Pending session events are buffered until the publisher catches up, failed batches are retried.
+// If the buffer is full the oldest events are dropped: their user events are lost
+// and the (retained) state of every room is republished instead, only the latest room state matters.
 type MQTTListener struct {
 	client                 mqtt.Client
+	db                     common.DBInterface
 	cache                  *AppCache
 	serviceProtocolHandler ServiceProtocolHandler
+	roomState              RoomStateFunc
+	lock                   sync.Mutex
+	pending                []*models.SessionEvent
+	overflow               bool // pending events were dropped
+	wake                   chan struct{}
+	stop                   chan struct{}
+	done                   chan struct{}
 }
 
-func NewMQTTListener(cache *AppCache, sph ServiceProtocolHandler) *MQTTListener {
+func NewMQTTListener(db common.DBInterface, cache *AppCache, sph ServiceProtocolHandler, roomState RoomStateFunc) *MQTTListener {
 	return &MQTTListener{
+		db:                     db,
 		cache:                  cache,
 		serviceProtocolHandler: sph,
+		roomState:              roomState,
+		wake:                   make(chan struct{}, 1),
+		stop:                   make(chan struct{}),
+		done:                   make(chan struct{}),
 	}
 }
 
@@ -62,6 +93,8 @@ func (l *MQTTListener) Start() error {
 		return pkgerr.Wrap(token.Error(), "mqtt.client Connect")
 	}
 
+	go l.run()
+
 	return nil
 }
 
@@ -72,6 +105,8 @@ func (l *MQTTListener) Subscribe(c mqtt.Client) {
 }
 
 func (l *MQTTListener) Close() {
+	close(l.stop)
+	<-l.done
 	l.client.Disconnect(1000)
 }
 
@@ -91,6 +126,128 @@ func (l *MQTTListener) HandleServiceProtocol(c mqtt.Client, m mqtt.Message) {
 	}
 }
 
+// Notify is called by session managers after their transactions commit.
+// We never block them, events are buffered and published in the background.
+func (l *MQTTListener) Notify(event interface{}) {
+	events, ok := event.([]*models.SessionEvent)
+	if !ok {
+		return
+	}
+
+	l.lock.Lock()
+	l.buffer(append(l.pending, events...))
+	l.lock.Unlock()
+
+	l.wakeUp()
+}
+
+// buffer sets the pending events, dropping the oldest ones beyond mqttPendingEvents. Caller must hold the lock.
+func (l *MQTTListener) buffer(events []*models.SessionEvent) {
+	if n := len(events) - mqttPendingEvents; n > 0 {
+		if !l.overflow {
+			log.Warn().Int("events", n).Msg("MQTTListener buffer is full, dropping session events and republishing all rooms")
+		}
+		l.overflow = true
+		copy(events, events[n:])
+		events = events[:mqttPendingEvents]
+	}
+	l.pending = events
+}
+
+func (l *MQTTListener) wakeUp() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (l *MQTTListener) run() {
+	defer close(l.done)
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-l.wake:
+			l.lock.Lock()
+			events, overflow := l.pending, l.overflow
+			l.pending, l.overflow = nil, false
+			l.lock.Unlock()
+
+			if overflow {
+				for _, room := range l.cache.rooms.Values() {
+					l.publishRoomState(room)
+				}
+			}
+
+			if err := l.process(events); err != nil {
+				log.Error().Err(err).Msg("MQTTListener.process")
+				l.retry(events)
+			}
+		}
+	}
+}
+
+func (l *MQTTListener) process(events []*models.SessionEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	roomEvents, rooms, err := sessionsRoomEvents(l.db, events)
+	if err != nil {
+		return err
+	}
+
+	l.PublishUserEvents(roomEvents)
+
+	for _, room := range rooms {
+		l.publishRoomState(room)
+	}
+
+	return nil
+}
+
+// retry puts back a failed batch of session events ahead of those pending and processes them again later
+func (l *MQTTListener) retry(events []*models.SessionEvent) {
+	l.lock.Lock()
+	l.buffer(append(events, l.pending...))
+	l.lock.Unlock()
+
+	time.AfterFunc(mqttRetryInterval, l.wakeUp)
+}
+
+func (l *MQTTListener) publishRoomState(room *models.Room) {
+	state, err := l.roomState(room.ID)
+	if err != nil {
+		log.Error().Err(err).Int64("room", room.ID).Msg("MQTTListener fetch room state")
+		return
+	}
+	l.publish(fmt.Sprintf("galaxy/rooms/%d", room.GatewayUID), true, state)
+}
+
+// PublishUserEvents publishes events which don't originate in session events, e.g. gateway migrate hints
+func (l *MQTTListener) PublishUserEvents(events []*V2RoomEvent) {
+	for _, e := range events {
+		if e.User != "" {
+			l.publish(fmt.Sprintf("galaxy/users/%s", e.User), false, e)
+		}
+	}
+}
+
+func (l *MQTTListener) publish(topic string, retained bool, payload interface{}) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		log.Error().Err(err).Str("topic", topic).Msg("MQTTListener json.Marshal")
+		return
+	}
+
+	token := l.client.Publish(topic, byte(1), retained, b)
+	go func() {
+		if token.Wait() && token.Error() != nil {
+			log.Error().Err(token.Error()).Str("topic", topic).Msg("mqtt.client Publish")
+		}
+	}()
+}
+
 type PahoLogAdapter struct {
 	level zerolog.Level
 }
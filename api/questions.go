@@ -0,0 +1,199 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	pkgerr "github.com/pkg/errors"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/middleware"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/httputil"
+)
+
+type V2Question struct {
+	ID         int64     `json:"id"`
+	Room       int       `json:"room"`
+	RoomName   string    `json:"room_name"`
+	User       string    `json:"user"`
+	Display    string    `json:"display"`
+	Status     string    `json:"status"`
+	RaisedAt   time.Time `json:"raised_at"`
+	ResolvedAt null.Time `json:"resolved_at"`
+	ResolvedBy string    `json:"resolved_by,omitempty"`
+}
+
+// questionResolvedGrace is how long after a question is resolved the user can't raise another one in that room.
+// Resolving lowers the user's question flag, clients still raising it in their next heartbeat mustn't re-raise the question.
+const questionResolvedGrace = time.Minute
+
+// syncQuestions keeps the questions queue in line with a session event.
+// A raised question flag opens a question in the session's room
+// (if the user has none open there and none was resolved there lately).
+// A lowered flag or leaving withdraws the open questions raised by the session,
+// those raised by other sessions of the user (e.g. in other rooms) are left as is.
+// Moving to another room moves a raised question to the back of the new room's queue.
+func syncQuestions(exec boil.Executor, event *models.SessionEvent) error {
+	switch event.Type {
+	case common.SessionEventQuestion:
+		if event.NewValue.String == "true" {
+			return raiseQuestion(exec, event.SessionID)
+		}
+		return withdrawQuestions(exec, event.SessionID)
+	case common.SessionEventClose, common.SessionEventClean:
+		return withdrawQuestions(exec, event.SessionID)
+	case common.SessionEventRoom:
+		if err := withdrawQuestions(exec, event.SessionID); err != nil {
+			return err
+		}
+		return raiseQuestion(exec, event.SessionID)
+	}
+
+	return nil
+}
+
+func raiseQuestion(exec boil.Executor, sessionID int64) error {
+	_, err := queries.Raw(`insert into questions (room_id, user_id, session_id, status)
+select s.room_id, s.user_id, s.id, $2 from sessions s
+where s.id = $1 and s.question and s.room_id is not null and not exists (
+  select 1 from questions q where q.room_id = s.room_id and q.user_id = s.user_id and
+    (q.status = $2 or (q.status in ($3, $4) and q.resolved_at > $5)))`,
+		sessionID, common.QuestionStatusOpen, common.QuestionStatusAnswered, common.QuestionStatusDismissed,
+		time.Now().UTC().Add(-questionResolvedGrace)).Exec(exec)
+	return pkgerr.Wrap(err, "db insert question")
+}
+
+func withdrawQuestions(exec boil.Executor, sessionID int64) error {
+	_, err := queries.Raw("update questions set status = $1, resolved_at = $2 where session_id = $3 and status = $4",
+		common.QuestionStatusWithdrawn, time.Now().UTC(), sessionID, common.QuestionStatusOpen).Exec(exec)
+	return pkgerr.Wrap(err, "db withdraw questions")
+}
+
+func (a *App) V2ListRoomQuestions(w http.ResponseWriter, r *http.Request) {
+	room, ok := a.cache.rooms.ByGatewayUID(mux.Vars(r)["id"])
+	if !ok {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	a.listQuestions(w, r, models.QuestionWhere.RoomID.EQ(room.ID))
+}
+
+func (a *App) V2ListQuestions(w http.ResponseWriter, r *http.Request) {
+	a.listQuestions(w, r)
+}
+
+func (a *App) listQuestions(w http.ResponseWriter, r *http.Request, mods ...qm.QueryMod) {
+	query := r.URL.Query()
+	if query.Get("all") != "true" {
+		mods = append(mods, models.QuestionWhere.Status.EQ(common.QuestionStatusOpen))
+	}
+
+	since, err := parseTimeParam(query, "since")
+	if err != nil {
+		httputil.NewBadRequestError(err, err.Error()).Abort(w, r)
+		return
+	}
+	if since.Valid {
+		mods = append(mods, models.QuestionWhere.RaisedAt.GTE(since.Time))
+	}
+
+	mods = append(mods,
+		qm.Load(models.QuestionRels.Room),
+		qm.Load(models.QuestionRels.User),
+		qm.Load(models.QuestionRels.Session),
+		qm.OrderBy("raised_at asc, id asc"),
+	)
+	questions, err := models.Questions(mods...).All(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	resp := make([]*V2Question, len(questions))
+	for i, q := range questions {
+		resp[i] = makeV2Question(q)
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (a *App) V2AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	a.resolveQuestion(w, r, common.QuestionStatusAnswered)
+}
+
+func (a *App) V2DismissQuestion(w http.ResponseWriter, r *http.Request) {
+	a.resolveQuestion(w, r, common.QuestionStatusDismissed)
+}
+
+func (a *App) resolveQuestion(w http.ResponseWriter, r *http.Request, status string) {
+	if !common.Config.SkipPermissions && !middleware.RequestHasRole(r, common.RoleShidur, common.RoleAdmin, common.RoleRoot) {
+		httputil.NewForbiddenError().Abort(w, r)
+		return
+	}
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		httputil.NewNotFoundError().Abort(w, r)
+		return
+	}
+
+	var by string
+	if rCtx := a.requestContext(r); rCtx.IDClaims != nil {
+		by = rCtx.IDClaims.Sub
+	}
+
+	if err := a.sessionManager.ResolveQuestion(r.Context(), id, status, by); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			httputil.NewNotFoundError().Abort(w, r)
+		} else {
+			httputil.NewInternalError(err).Abort(w, r)
+		}
+		return
+	}
+
+	question, err := models.Questions(
+		models.QuestionWhere.ID.EQ(id),
+		qm.Load(models.QuestionRels.Room),
+		qm.Load(models.QuestionRels.User),
+		qm.Load(models.QuestionRels.Session),
+	).One(a.DB)
+	if err != nil {
+		httputil.NewInternalError(pkgerr.WithStack(err)).Abort(w, r)
+		return
+	}
+
+	httputil.RespondWithJSON(w, http.StatusOK, makeV2Question(question))
+}
+
+func makeV2Question(q *models.Question) *V2Question {
+	resp := &V2Question{
+		ID:         q.ID,
+		Status:     q.Status,
+		RaisedAt:   q.RaisedAt,
+		ResolvedAt: q.ResolvedAt,
+		ResolvedBy: q.ResolvedBy.String,
+	}
+	if q.R != nil {
+		if q.R.Room != nil {
+			resp.Room = q.R.Room.GatewayUID
+			resp.RoomName = q.R.Room.Name
+		}
+		if q.R.User != nil {
+			resp.User = q.R.User.AccountsID
+		}
+		if q.R.Session != nil {
+			resp.Display = q.R.Session.Display.String
+		}
+	}
+	return resp
+}
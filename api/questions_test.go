@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+func (s *ApiTestSuite) TestQuestions_Queue() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	users := []*models.User{s.CreateUser(), s.CreateUser()}
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	v1Users := make([]*V1User, len(users))
+	for i, user := range users {
+		v1Users[i] = s.makeV1user(gateway, room, user)
+		s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1Users[i]))
+	}
+
+	// raise in reverse order, twice
+	for i := len(v1Users) - 1; i >= 0; i-- {
+		v1Users[i].Question = true
+		s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1Users[i]))
+		s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1Users[i]))
+	}
+
+	questions := s.listQuestions(fmt.Sprintf("/v2/rooms/%d/questions", room.GatewayUID))
+	s.Require().Len(questions, 2, "questions")
+	s.Equal(users[1].AccountsID, questions[0].User, "first question")
+	s.Equal(users[0].AccountsID, questions[1].User, "second question")
+	s.Equal(common.QuestionStatusOpen, questions[0].Status, "status")
+
+	// lower hand
+	v1Users[1].Question = false
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1Users[1]))
+
+	questions = s.listQuestions("/v2/questions")
+	s.Require().Len(questions, 1, "questions")
+	s.Equal(users[0].AccountsID, questions[0].User, "remaining question")
+	s.Equal(room.GatewayUID, questions[0].Room, "question room")
+
+	questions = s.listQuestions("/v2/questions?all=true")
+	s.Require().Len(questions, 2, "all questions")
+	s.Equal(common.QuestionStatusWithdrawn, questions[0].Status, "withdrawn")
+}
+
+func (s *ApiTestSuite) TestQuestions_TwoSessions() {
+	gateway := s.CreateGateway()
+	rooms := []*models.Room{s.CreateRoom(gateway), s.CreateRoom(gateway)}
+	user := s.CreateUser()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	v1Users := make([]*V1User, len(rooms))
+	for i, room := range rooms {
+		v1Users[i] = s.makeV1user(gateway, room, user)
+		v1Users[i].Question = true
+		s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1Users[i]))
+	}
+
+	questions := s.listQuestions("/v2/questions")
+	s.Require().Len(questions, 2, "questions")
+
+	// close the session in the first room
+	session, err := models.Sessions(
+		models.SessionWhere.UserID.EQ(user.ID),
+		models.SessionWhere.GatewaySession.EQ(null.Int64From(v1Users[0].Session)),
+	).One(s.DB)
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.sessionManager.CloseSessions(context.TODO(), user.ID, []int64{session.ID},
+		common.SessionEventSourceAdmin, map[string]interface{}{"reason": "test"}))
+
+	questions = s.listQuestions("/v2/questions")
+	s.Require().Len(questions, 1, "questions")
+	s.Equal(rooms[1].GatewayUID, questions[0].Room, "other session question room")
+}
+
+func (s *ApiTestSuite) TestQuestions_Resolve() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	v1User := s.makeV1user(gateway, room, user)
+	v1User.Question = true
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+
+	questions := s.listQuestions("/v2/questions")
+	s.Require().Len(questions, 1, "questions")
+
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/v2/questions/%d/answer", questions[0].ID), nil)
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusForbidden, resp.Code)
+
+	req, _ = http.NewRequest("POST", "/v2/questions/0/answer", nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	// question flag in another room
+	otherSession := s.CreateSession(user, gateway, s.CreateRoom(gateway))
+	otherSession.Question = true
+	_, err := otherSession.Update(s.DB, boil.Whitelist(models.SessionColumns.Question))
+	s.Require().NoError(err)
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/v2/questions/%d/answer", questions[0].ID), nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	body := s.request200json(req)
+	s.Equal(common.QuestionStatusAnswered, body["status"], "status")
+	s.Equal("Subject", body["resolved_by"], "resolved_by")
+
+	session, err := models.Sessions(
+		models.SessionWhere.UserID.EQ(user.ID),
+		models.SessionWhere.RoomID.EQ(null.Int64From(room.ID)),
+	).One(s.DB)
+	s.Require().NoError(err)
+	s.False(session.Question, "session.Question")
+	s.Require().NoError(otherSession.Reload(s.DB))
+	s.True(otherSession.Question, "other room session.Question")
+	events, err := session.SessionEvents(models.SessionEventWhere.Source.EQ(common.SessionEventSourceShidur)).All(s.DB)
+	s.Require().NoError(err)
+	s.Require().Len(events, 1, "shidur events")
+	s.Equal(common.SessionEventQuestion, events[0].Type, "event type")
+
+	// heartbeats still raising the flag don't re-raise the question
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+	s.Empty(s.listQuestions("/v2/questions"), "re-raised")
+
+	// already resolved
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/v2/questions/%d/dismiss", questions[0].ID), nil)
+	s.apiAuthP(req, []string{common.RoleShidur})
+	resp = s.request(req)
+	s.Require().Equal(http.StatusNotFound, resp.Code)
+
+	s.Empty(s.listQuestions("/v2/questions"), "open questions")
+
+	// admins can resolve questions as well
+	_, err = models.Questions(models.QuestionWhere.UserID.EQ(user.ID)).UpdateAll(s.DB, models.M{
+		models.QuestionColumns.ResolvedAt: time.Now().UTC().Add(-questionResolvedGrace),
+	})
+	s.Require().NoError(err)
+	v1User.Question = false
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+	v1User.Question = true
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+	questions = s.listQuestions("/v2/questions")
+	s.Require().Len(questions, 1, "raised again")
+
+	req, _ = http.NewRequest("POST", fmt.Sprintf("/v2/questions/%d/dismiss", questions[0].ID), nil)
+	s.apiAuthP(req, []string{common.RoleAdmin})
+	body = s.request200json(req)
+	s.Equal(common.QuestionStatusDismissed, body["status"], "admin status")
+}
+
+func (s *ApiTestSuite) listQuestions(url string) []*V2Question {
+	req, _ := http.NewRequest("GET", url, nil)
+	s.apiAuth(req)
+	resp := s.request(req)
+	s.Require().Equal(http.StatusOK, resp.Code)
+	var questions []*V2Question
+	s.Require().NoError(json.Unmarshal(resp.Body.Bytes(), &questions))
+	return questions
+}
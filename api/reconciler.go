@@ -0,0 +1,323 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	janus_admin "github.com/edoshor/janus-go/admin"
+	"github.com/lib/pq"
+	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/queries"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/domain"
+	"github.com/Bnei-Baruch/gxydb-api/instrumentation"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/patterns"
+)
+
+type ReconcileReport struct {
+	StartedAt time.Time                          `json:"started_at"`
+	Duration  string                             `json:"duration"`
+	Gateways  map[string]*GatewayReconcileReport `json:"gateways"`
+}
+
+type GatewayReconcileReport struct {
+	DBSessions      int      `json:"db_sessions"`
+	GatewaySessions int      `json:"gateway_sessions"`
+	Closed          []int64  `json:"closed"`
+	Unknown         []uint64 `json:"unknown"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// reconcileProbeMaxAge is how old the gateway sessions listed by the periodic health probe may be for us to use them
+const reconcileProbeMaxAge = 10 * time.Second
+
+// reconcileMinAge skips sessions too young to be in the gateway sessions we compare against
+const reconcileMinAge = 10 * time.Second
+
+// SessionReconciler compares open sessions in DB with what rooms gateways actually have.
+// DB sessions whose gateway session (or handle) is gone are closed.
+// Gateway sessions unknown to the DB are only reported.
+// Gateway sessions are taken from the periodic health probe if it's fresh, otherwise gateways are asked directly.
+type SessionReconciler struct {
+	*patterns.SimpleObservable
+	ticker  *time.Ticker
+	db      common.DBInterface
+	cache   *AppCache
+	running sync.Mutex // one reconcile at a time
+	lock    sync.Mutex // guards report
+	report  *ReconcileReport
+}
+
+func NewSessionReconciler(db common.DBInterface, cache *AppCache) *SessionReconciler {
+	return &SessionReconciler{
+		SimpleObservable: patterns.NewSimpleObservable(),
+		db:               db,
+		cache:            cache,
+	}
+}
+
+func (sr *SessionReconciler) Start() {
+	if common.Config.ReconcileInterval <= 0 {
+		return
+	}
+
+	log.Info().Msg("periodically reconciling sessions")
+	sr.ticker = time.NewTicker(common.Config.ReconcileInterval)
+	go sr.run()
+}
+
+func (sr *SessionReconciler) Close() {
+	if sr.ticker != nil {
+		sr.ticker.Stop()
+	}
+}
+
+func (sr *SessionReconciler) run() {
+	for range sr.ticker.C {
+		sr.Reconcile()
+	}
+}
+
+func (sr *SessionReconciler) LastReport() *ReconcileReport {
+	sr.lock.Lock()
+	defer sr.lock.Unlock()
+	return sr.report
+}
+
+func (sr *SessionReconciler) Reconcile() *ReconcileReport {
+	sr.running.Lock()
+	defer sr.running.Unlock()
+
+	report := &ReconcileReport{
+		StartedAt: time.Now().UTC(),
+		Gateways:  make(map[string]*GatewayReconcileReport),
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, gateway := range sr.cache.gateways.Values() {
+		if gateway.Disabled || gateway.RemovedAt.Valid || gateway.Type != common.GatewayTypeRooms {
+			continue
+		}
+
+		wg.Add(1)
+		go func(g *models.Gateway) {
+			defer wg.Done()
+			gReport := sr.reconcileGateway(g)
+			mu.Lock()
+			report.Gateways[g.Name] = gReport
+			mu.Unlock()
+		}(gateway)
+	}
+	wg.Wait()
+
+	report.Duration = time.Now().Sub(report.StartedAt).String()
+
+	instrumentation.Stats.ReconcileUnknownGauge.Reset()
+	for name, gReport := range report.Gateways {
+		if gReport.Error != "" {
+			log.Error().Str("gateway", name).Str("error", gReport.Error).Msg("SessionReconciler gateway error")
+			continue
+		}
+		instrumentation.Stats.ReconcileClosedCounter.WithLabelValues(name).Add(float64(len(gReport.Closed)))
+		instrumentation.Stats.ReconcileUnknownGauge.WithLabelValues(name).Set(float64(len(gReport.Unknown)))
+		log.Info().
+			Str("gateway", name).
+			Int("db", gReport.DBSessions).
+			Int("gateway_sessions", gReport.GatewaySessions).
+			Int("closed", len(gReport.Closed)).
+			Int("unknown", len(gReport.Unknown)).
+			Msg("SessionReconciler summary")
+	}
+
+	sr.lock.Lock()
+	sr.report = report
+	sr.lock.Unlock()
+
+	return report
+}
+
+func (sr *SessionReconciler) reconcileGateway(gateway *models.Gateway) *GatewayReconcileReport {
+	report := &GatewayReconcileReport{
+		Closed:  make([]int64, 0),
+		Unknown: make([]uint64, 0),
+	}
+
+	api, err := domain.GatewayAdminAPIRegistry.For(gateway)
+	if err != nil {
+		report.Error = pkgerr.WithMessage(err, "Admin API for gateway").Error()
+		return report
+	}
+
+	gSessionIDs, listedAt, err := gatewaySessionIDs(gateway, api)
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.GatewaySessions = len(gSessionIDs)
+
+	sessions, err := models.Sessions(
+		models.SessionWhere.GatewayID.EQ(null.Int64From(gateway.ID)),
+		models.SessionWhere.RemovedAt.IsNull(),
+		models.SessionWhere.CreatedAt.LT(listedAt.Add(-reconcileMinAge)),
+	).All(sr.db)
+	if err != nil {
+		report.Error = pkgerr.Wrap(err, "db fetch sessions").Error()
+		return report
+	}
+	report.DBSessions = len(sessions)
+
+	gSessions := make(map[uint64]bool, len(gSessionIDs))
+	for _, id := range gSessionIDs {
+		gSessions[id] = false
+	}
+
+	// handles are fetched lazily, once per gateway session
+	gHandles := make(map[uint64]map[uint64]bool)
+	handlesOf := func(sessionID uint64) (map[uint64]bool, error) {
+		if handles, ok := gHandles[sessionID]; ok {
+			return handles, nil
+		}
+		apiRes, err := api.ListHandles(sessionID)
+		if err != nil {
+			return nil, pkgerr.Wrap(err, "api.ListHandles")
+		}
+		tApiRes, ok := apiRes.(*janus_admin.ListHandlesResponse)
+		if !ok {
+			return nil, pkgerr.Errorf("unexpected api.ListHandles response: %+v", apiRes)
+		}
+		handles := make(map[uint64]bool, len(tApiRes.Handles))
+		for _, id := range tApiRes.Handles {
+			handles[id] = true
+		}
+		gHandles[sessionID] = handles
+		return handles, nil
+	}
+
+	gone := make(map[int64]string)
+	for _, session := range sessions {
+		if !session.GatewaySession.Valid {
+			continue
+		}
+
+		gSessionID := uint64(session.GatewaySession.Int64)
+		if _, ok := gSessions[gSessionID]; !ok {
+			gone[session.ID] = "gateway_session_gone"
+			continue
+		}
+		gSessions[gSessionID] = true
+
+		if !session.GatewayHandle.Valid {
+			continue
+		}
+		handles, err := handlesOf(gSessionID)
+		if err != nil {
+			// gateway session might have just been destroyed, we'll get it next time.
+			log.Warn().Err(err).Str("gateway", gateway.Name).Uint64("session", gSessionID).
+				Msg("SessionReconciler list handles")
+			continue
+		}
+		if !handles[uint64(session.GatewayHandle.Int64)] {
+			gone[session.ID] = "gateway_handle_gone"
+		}
+	}
+
+	for id, known := range gSessions {
+		if !known {
+			report.Unknown = append(report.Unknown, id)
+		}
+	}
+
+	if len(gone) > 0 {
+		closed, err := sr.closeSessions(gone)
+		if err != nil {
+			report.Error = pkgerr.WithMessage(err, "close sessions").Error()
+			return report
+		}
+		report.Closed = closed
+	}
+
+	return report
+}
+
+// gatewaySessionIDs returns the sessions on the gateway and when they were listed.
+// The periodic health probe lists them anyway, we only ask the gateway if the probe is stale.
+func gatewaySessionIDs(gateway *models.Gateway, api janus_admin.AdminAPI) ([]uint64, time.Time, error) {
+	if ids, listedAt, ok := domain.GatewayHealthRegistry.SessionIDs(gateway); ok && time.Since(listedAt) < reconcileProbeMaxAge {
+		return ids, listedAt, nil
+	}
+
+	listedAt := time.Now().UTC()
+	apiRes, err := api.ListSessions()
+	if err != nil {
+		return nil, listedAt, pkgerr.Wrap(err, "api.ListSessions")
+	}
+	tApiRes, ok := apiRes.(*janus_admin.ListSessionsResponse)
+	if !ok {
+		return nil, listedAt, pkgerr.Errorf("unexpected api.ListSessions response: %+v", apiRes)
+	}
+	return tApiRes.Sessions, listedAt, nil
+}
+
+// closeSessions closes the given DB sessions (id -> reason) and returns the ids of those actually closed
+func (sr *SessionReconciler) closeSessions(reasons map[int64]string) ([]int64, error) {
+	b, err := json.Marshal(map[string]interface{}{
+		"reconcile_session": time.Now().UTC(),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("SessionReconciler json.Marshal")
+	}
+
+	ids := make([]int64, 0, len(reasons))
+	for id := range reasons {
+		ids = append(ids, id)
+	}
+
+	closed := make([]int64, 0, len(ids))
+	err = inSessionTx(context.TODO(), sr.db, sr, func(tx *sessionTx) error {
+		rows, err := queries.Raw("update sessions set properties = coalesce(properties, '{}'::jsonb) || $1, removed_at = $2 where id = ANY($3) and removed_at is null returning id, user_id",
+			string(b), time.Now().UTC(), pq.Array(ids),
+		).Query(tx)
+		if err != nil {
+			return pkgerr.Wrap(err, "db update sessions")
+		}
+		defer rows.Close()
+
+		events := make([]*models.SessionEvent, 0, len(ids))
+		for rows.Next() {
+			var id, userID int64
+			if err := rows.Scan(&id, &userID); err != nil {
+				return pkgerr.Wrap(err, "rows.Scan")
+			}
+			closed = append(closed, id)
+
+			props, _ := json.Marshal(map[string]interface{}{
+				"reason": reasons[id],
+			})
+			events = append(events, &models.SessionEvent{
+				SessionID:  id,
+				UserID:     userID,
+				Type:       common.SessionEventClose,
+				Source:     common.SessionEventSourceReconciler,
+				Properties: null.JSONFrom(props),
+			})
+		}
+		if err := rows.Err(); err != nil {
+			return pkgerr.Wrap(err, "rows.Err")
+		}
+
+		return tx.insertEvents(events...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return closed, nil
+}
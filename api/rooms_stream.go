@@ -0,0 +1,404 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/patterns"
+)
+
+const (
+	RoomEventEnter    = "enter"
+	RoomEventLeave    = "leave"
+	RoomEventQuestion = "question"
+	RoomEventCamera   = "camera"
+	RoomEventNumUsers = "num_users"
+	RoomEventMigrate  = "migrate"
+)
+
+const (
+	roomsStreamBacklog     = 1000 // events kept for Last-Event-ID resume
+	roomsStreamQueue       = 100  // pending session events batches
+	roomsStreamSubscribers = 100  // per subscriber buffered events
+)
+
+type V2RoomEvent struct {
+	ID       string `json:"-"`
+	Type     string `json:"type"`
+	Room     int    `json:"room"`
+	User     string `json:"user,omitempty"`
+	Display  string `json:"display,omitempty"`
+	Value    *bool  `json:"value,omitempty"`
+	NumUsers *int   `json:"num_users,omitempty"`
+	Gateway  string `json:"gateway,omitempty"`
+	roomID   int64
+}
+
+// RoomsStream observes session events and turns them into room events for SSE subscribers.
+// Recent events are kept in memory so reconnecting clients could resume from their Last-Event-ID.
+// Event IDs are "<epoch>-<seq>" where epoch changes on every process start
+// and whenever session events are dropped, so clients never resume across a gap.
+// Observers are notified with the []*V2RoomEvent published for every batch of session events.
+type RoomsStream struct {
+	*patterns.SimpleObservable
+	db          common.DBInterface
+	queue       chan []*models.SessionEvent
+	stop        chan struct{}
+	done        chan struct{}
+	lock        sync.RWMutex
+	epoch       string
+	seq         int64
+	backlog     []*V2RoomEvent
+	subscribers map[chan *V2RoomEvent]struct{}
+	numUsers    map[int64]int
+}
+
+func NewRoomsStream(db common.DBInterface) *RoomsStream {
+	return &RoomsStream{
+		SimpleObservable: patterns.NewSimpleObservable(),
+		db:               db,
+		queue:            make(chan []*models.SessionEvent, roomsStreamQueue),
+		stop:             make(chan struct{}),
+		done:             make(chan struct{}),
+		epoch:            newRoomsStreamEpoch(""),
+		backlog:          make([]*V2RoomEvent, 0, roomsStreamBacklog),
+		subscribers:      make(map[chan *V2RoomEvent]struct{}),
+		numUsers:         make(map[int64]int),
+	}
+}
+
+func (rs *RoomsStream) Start() {
+	go rs.run()
+}
+
+func (rs *RoomsStream) Close() {
+	close(rs.stop)
+	<-rs.done
+
+	rs.lock.Lock()
+	for ch := range rs.subscribers {
+		close(ch)
+		delete(rs.subscribers, ch)
+	}
+	rs.lock.Unlock()
+}
+
+// Notify is called by session managers after their transactions commit.
+// We never block them, if we can't keep up events are dropped and the stream is reset.
+func (rs *RoomsStream) Notify(event interface{}) {
+	events, ok := event.([]*models.SessionEvent)
+	if !ok {
+		return
+	}
+
+	select {
+	case rs.queue <- events:
+	default:
+		log.Warn().Int("events", len(events)).Msg("RoomsStream queue is full, dropping session events")
+		rs.reset()
+	}
+}
+
+// reset starts a new epoch with an empty backlog and disconnects all subscribers.
+// Events were lost so neither resuming from Last-Event-ID nor the current subscribers could be trusted,
+// clients should reconnect and start with a fresh snapshot.
+func (rs *RoomsStream) reset() {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.epoch = newRoomsStreamEpoch(rs.epoch)
+	rs.seq = 0
+	rs.backlog = rs.backlog[:0]
+	for ch := range rs.subscribers {
+		close(ch)
+		delete(rs.subscribers, ch)
+	}
+}
+
+func newRoomsStreamEpoch(prev string) string {
+	for {
+		if epoch := strconv.FormatInt(time.Now().UnixNano(), 36); epoch != prev {
+			return epoch
+		}
+	}
+}
+
+func (rs *RoomsStream) run() {
+	defer close(rs.done)
+	for {
+		select {
+		case <-rs.stop:
+			return
+		case events := <-rs.queue:
+			if err := rs.process(events); err != nil {
+				log.Error().Err(err).Msg("RoomsStream.process")
+			}
+		}
+	}
+}
+
+func (rs *RoomsStream) process(events []*models.SessionEvent) error {
+	roomEvents, roomsByID, err := sessionsRoomEvents(rs.db, events)
+	if err != nil {
+		return err
+	}
+
+	published := make([]*V2RoomEvent, 0, len(roomEvents))
+	emit := func(event *V2RoomEvent) {
+		rs.publish(event)
+		published = append(published, event)
+	}
+
+	for _, event := range roomEvents {
+		emit(event)
+	}
+
+	err = rs.publishNumUsers(roomsByID, emit)
+
+	if len(published) > 0 {
+		rs.NotifyAll(published)
+	}
+
+	return err
+}
+
+// sessionsRoomEvents turns session events into room events.
+// The rooms involved, including rooms left by a room change, are returned by their id.
+func sessionsRoomEvents(db common.DBInterface, events []*models.SessionEvent) ([]*V2RoomEvent, map[int64]*models.Room, error) {
+	sessionIDs := make([]int64, 0, len(events))
+	for _, event := range events {
+		sessionIDs = append(sessionIDs, event.SessionID)
+	}
+
+	sessions, err := models.Sessions(
+		qm.Where("id = ANY(?)", pq.Array(sessionIDs)),
+		qm.Load(models.SessionRels.User),
+	).All(db)
+	if err != nil {
+		return nil, nil, pkgerr.Wrap(err, "db fetch sessions")
+	}
+	sessionsByID := make(map[int64]*models.Session, len(sessions))
+	roomIDs := make([]int64, 0)
+	for _, session := range sessions {
+		sessionsByID[session.ID] = session
+		if session.RoomID.Valid {
+			roomIDs = append(roomIDs, session.RoomID.Int64)
+		}
+	}
+	for _, event := range events {
+		if event.Type == common.SessionEventRoom && event.OldValue.Valid {
+			if id, err := strconv.ParseInt(event.OldValue.String, 10, 64); err == nil {
+				roomIDs = append(roomIDs, id)
+			}
+		}
+	}
+
+	rooms, err := models.Rooms(qm.Where("id = ANY(?)", pq.Array(roomIDs))).All(db)
+	if err != nil {
+		return nil, nil, pkgerr.Wrap(err, "db fetch rooms")
+	}
+	roomsByID := make(map[int64]*models.Room, len(rooms))
+	for _, room := range rooms {
+		roomsByID[room.ID] = room
+	}
+
+	roomEvents := make([]*V2RoomEvent, 0, len(events))
+
+	for _, event := range events {
+		session, ok := sessionsByID[event.SessionID]
+		if !ok || !session.RoomID.Valid {
+			continue
+		}
+		room, ok := roomsByID[session.RoomID.Int64]
+		if !ok {
+			continue
+		}
+
+		roomEvent := &V2RoomEvent{
+			Room:    room.GatewayUID,
+			Display: session.Display.String,
+			roomID:  room.ID,
+		}
+		if session.R.User != nil {
+			roomEvent.User = session.R.User.AccountsID
+		}
+
+		switch event.Type {
+		case common.SessionEventEnter, common.SessionEventRevive:
+			roomEvent.Type = RoomEventEnter
+		case common.SessionEventClose, common.SessionEventClean:
+			roomEvent.Type = RoomEventLeave
+		case common.SessionEventRoom:
+			if id, err := strconv.ParseInt(event.OldValue.String, 10, 64); err == nil {
+				if oldRoom, ok := roomsByID[id]; ok {
+					leave := *roomEvent
+					leave.Type = RoomEventLeave
+					leave.Room = oldRoom.GatewayUID
+					leave.roomID = oldRoom.ID
+					roomEvents = append(roomEvents, &leave)
+				}
+			}
+			roomEvent.Type = RoomEventEnter
+		case common.SessionEventQuestion, common.SessionEventCamera:
+			value, err := strconv.ParseBool(event.NewValue.String)
+			if err != nil {
+				continue
+			}
+			roomEvent.Type = RoomEventQuestion
+			if event.Type == common.SessionEventCamera {
+				roomEvent.Type = RoomEventCamera
+			}
+			roomEvent.Value = &value
+		default:
+			continue
+		}
+
+		roomEvents = append(roomEvents, roomEvent)
+	}
+
+	return roomEvents, roomsByID, nil
+}
+
+func (rs *RoomsStream) publishNumUsers(rooms map[int64]*models.Room, emit func(*V2RoomEvent)) error {
+	if len(rooms) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(rooms))
+	for id := range rooms {
+		ids = append(ids, id)
+	}
+
+	rows, err := queries.Raw("select room_id, count(distinct user_id) from sessions where removed_at is null and room_id = ANY($1) group by room_id",
+		pq.Array(ids)).Query(rs.db)
+	if err != nil {
+		return pkgerr.Wrap(err, "db count users")
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]int, len(ids))
+	for rows.Next() {
+		var roomID int64
+		var count int
+		if err := rows.Scan(&roomID, &count); err != nil {
+			return pkgerr.Wrap(err, "rows.Scan")
+		}
+		counts[roomID] = count
+	}
+	if err := rows.Err(); err != nil {
+		return pkgerr.Wrap(err, "rows.Err")
+	}
+
+	for id, room := range rooms {
+		count := counts[id]
+		if prev, ok := rs.numUsers[id]; ok && prev == count {
+			continue
+		}
+		if count == 0 {
+			delete(rs.numUsers, id)
+		} else {
+			rs.numUsers[id] = count
+		}
+		emit(&V2RoomEvent{
+			Type:     RoomEventNumUsers,
+			Room:     room.GatewayUID,
+			NumUsers: &count,
+			roomID:   room.ID,
+		})
+	}
+
+	return nil
+}
+
+// Publish publishes events which don't originate in session events, e.g. gateway migrate hints
+func (rs *RoomsStream) Publish(events []*V2RoomEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		rs.publish(event)
+	}
+	rs.NotifyAll(events)
+}
+
+func (rs *RoomsStream) publish(event *V2RoomEvent) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.seq++
+	event.ID = fmt.Sprintf("%s-%d", rs.epoch, rs.seq)
+
+	if len(rs.backlog) == roomsStreamBacklog {
+		copy(rs.backlog, rs.backlog[1:])
+		rs.backlog = rs.backlog[:len(rs.backlog)-1]
+	}
+	rs.backlog = append(rs.backlog, event)
+
+	for ch := range rs.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// slow consumer, drop it. It would resume with Last-Event-ID.
+			close(ch)
+			delete(rs.subscribers, ch)
+		}
+	}
+}
+
+// Subscribe registers a new subscriber.
+// If lastEventID could be resumed from, the events following it are returned with ok = true.
+// Otherwise the subscriber should start with a snapshot, lastID is the ID of the last event published so far.
+func (rs *RoomsStream) Subscribe(lastEventID string) (ch chan *V2RoomEvent, missed []*V2RoomEvent, lastID string, ok bool) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	ch = make(chan *V2RoomEvent, roomsStreamSubscribers)
+	rs.subscribers[ch] = struct{}{}
+	lastID = fmt.Sprintf("%s-%d", rs.epoch, rs.seq)
+
+	missed, ok = rs.since(lastEventID)
+	return
+}
+
+func (rs *RoomsStream) Unsubscribe(ch chan *V2RoomEvent) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	if _, ok := rs.subscribers[ch]; ok {
+		close(ch)
+		delete(rs.subscribers, ch)
+	}
+}
+
+// since returns the events in backlog following the given id. Caller must hold the lock.
+func (rs *RoomsStream) since(id string) ([]*V2RoomEvent, bool) {
+	s := strings.SplitN(id, "-", 2)
+	if len(s) != 2 || s[0] != rs.epoch {
+		return nil, false
+	}
+	seq, err := strconv.ParseInt(s[1], 10, 64)
+	if err != nil || seq > rs.seq {
+		return nil, false
+	}
+
+	first := rs.seq - int64(len(rs.backlog)) + 1 // seq of backlog[0]
+	if seq < first-1 {
+		return nil, false // too old
+	}
+
+	missed := make([]*V2RoomEvent, len(rs.backlog[seq-first+1:]))
+	copy(missed, rs.backlog[seq-first+1:])
+	return missed, true
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/volatiletech/null"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+func (s *ApiTestSuite) TestRoomsStream_Process() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+
+	rs := NewRoomsStream(s.DB)
+	ch, _, lastID, resumed := rs.Subscribe("")
+	s.False(resumed, "resumed with no Last-Event-ID")
+	defer rs.Unsubscribe(ch)
+
+	enter := newSessionEvent(session, common.SessionEventEnter, common.SessionEventSourceProtocol)
+	question := newSessionEvent(session, common.SessionEventQuestion, common.SessionEventSourceProtocol)
+	question.OldValue = null.StringFrom("false")
+	question.NewValue = null.StringFrom("true")
+	s.Require().NoError(rs.process([]*models.SessionEvent{enter, question}))
+
+	s.Require().Len(ch, 3, "published events")
+	event := <-ch
+	s.Equal(RoomEventEnter, event.Type, "event.Type")
+	s.Equal(room.GatewayUID, event.Room, "event.Room")
+	s.Equal(user.AccountsID, event.User, "event.User")
+	event = <-ch
+	s.Equal(RoomEventQuestion, event.Type, "event.Type")
+	s.Require().NotNil(event.Value, "event.Value")
+	s.True(*event.Value, "event.Value")
+	event = <-ch
+	s.Equal(RoomEventNumUsers, event.Type, "event.Type")
+	s.Require().NotNil(event.NumUsers, "event.NumUsers")
+	s.Equal(1, *event.NumUsers, "event.NumUsers")
+
+	// num_users didn't change
+	s.Require().NoError(rs.process([]*models.SessionEvent{question}))
+	s.Require().Len(ch, 1, "published events")
+	<-ch
+
+	// resume
+	ch2, missed, _, resumed := rs.Subscribe(lastID)
+	defer rs.Unsubscribe(ch2)
+	s.True(resumed, "resumed")
+	s.Len(missed, 4, "missed events")
+
+	_, missed, _, resumed = rs.Subscribe(missed[1].ID)
+	s.True(resumed, "resumed")
+	s.Len(missed, 2, "missed events")
+
+	_, _, _, resumed = rs.Subscribe("unknown-1")
+	s.False(resumed, "resumed with unknown epoch")
+}
+
+func (s *ApiTestSuite) TestRoomsStream_Notify() {
+	user := s.CreateUser()
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	ch, _, _, _ := s.app.roomsStream.Subscribe("")
+	defer s.app.roomsStream.Unsubscribe(ch)
+
+	v1User := s.makeV1user(gateway, room, user)
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+
+	for _, eType := range []string{RoomEventEnter, RoomEventNumUsers} {
+		select {
+		case event := <-ch:
+			s.Equal(eType, event.Type, "event.Type")
+			s.Equal(room.GatewayUID, event.Room, "event.Room")
+		case <-time.After(time.Second):
+			s.FailNow("timeout waiting for room event", eType)
+		}
+	}
+}
+
+func (s *ApiTestSuite) TestRoomsStream_NotifyDropped() {
+	rs := NewRoomsStream(s.DB) // not started, nothing consumes the queue
+
+	rs.Publish([]*V2RoomEvent{{Type: RoomEventMigrate, Room: 1}})
+	ch, _, lastID, _ := rs.Subscribe("")
+	defer rs.Unsubscribe(ch)
+	_, _, _, resumed := rs.Subscribe(lastID)
+	s.True(resumed, "resumed before drop")
+
+	for i := 0; i <= roomsStreamQueue; i++ {
+		rs.Notify([]*models.SessionEvent{})
+	}
+
+	_, ok := <-ch
+	s.False(ok, "subscriber closed on drop")
+	_, _, newLastID, resumed := rs.Subscribe(lastID)
+	s.False(resumed, "resumed across dropped events")
+	s.NotEqual(lastID, newLastID, "new epoch")
+}
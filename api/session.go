@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"time"
 
 	"github.com/edoshor/janus-go"
@@ -19,38 +20,103 @@ import (
 	"github.com/volatiletech/sqlboiler/queries/qm"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/middleware"
 	"github.com/Bnei-Baruch/gxydb-api/models"
 	"github.com/Bnei-Baruch/gxydb-api/pkg/errs"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/patterns"
 	"github.com/Bnei-Baruch/gxydb-api/pkg/sqlutil"
 )
 
+// SessionManager notifies its observers with the []*models.SessionEvent
+// recorded by every committed transaction.
 type SessionManager interface {
+	patterns.Observable
 	HandleEvent(context.Context, interface{}) error
 	HandleProtocol(context.Context, *janus.TextroomPostMsg) error
 	UpsertSession(context.Context, *V1User) error
+	CloseSession(ctx context.Context, userID int64, source string, props map[string]interface{}) error
+	CloseSessions(ctx context.Context, userID int64, ids []int64, source string, props map[string]interface{}) error
+	ResolveQuestion(ctx context.Context, id int64, status, by string) error
 	Start()
 	Close()
 }
 
 type V1SessionManager struct {
+	*patterns.SimpleObservable
 	db      common.DBInterface
 	cache   *AppCache
 	cleaner *PeriodicSessionCleaner
 }
 
 func NewV1SessionManager(db common.DBInterface, cache *AppCache) SessionManager {
+	cleaner := NewPeriodicSessionCleaner(db)
 	return &V1SessionManager{
-		db:      db,
-		cache:   cache,
-		cleaner: NewPeriodicSessionCleaner(db),
+		SimpleObservable: cleaner.SimpleObservable, // cleaner events go to our observers as well
+		db:               db,
+		cache:            cache,
+		cleaner:          cleaner,
 	}
 }
 
+// sessionTx is a transaction keeping track of the session events inserted in it.
+type sessionTx struct {
+	*sql.Tx
+	events      []*models.SessionEvent
+	afterCommit []func()
+}
+
+func (tx *sessionTx) insertEvents(events ...*models.SessionEvent) error {
+	for _, event := range events {
+		if err := event.Insert(tx, boil.Infer()); err != nil {
+			return pkgerr.Wrap(err, "db insert session event")
+		}
+		if err := syncQuestions(tx, event); err != nil {
+			return pkgerr.WithMessage(err, "syncQuestions")
+		}
+		tx.events = append(tx.events, event)
+	}
+	return nil
+}
+
+// inSessionTx runs f in a transaction and notifies observers with the session events it recorded once committed.
+func inSessionTx(ctx context.Context, db common.DBInterface, observable patterns.Observable, f func(*sessionTx) error) error {
+	var stx *sessionTx
+	err := sqlutil.InTx(ctx, db, func(tx *sql.Tx) error {
+		stx = &sessionTx{Tx: tx}
+		return f(stx)
+	})
+	if err != nil {
+		return err
+	}
+	for _, fn := range stx.afterCommit {
+		fn()
+	}
+	if len(stx.events) > 0 {
+		observable.NotifyAll(stx.events)
+	}
+	return nil
+}
+
 func (sm *V1SessionManager) HandleEvent(ctx context.Context, event interface{}) error {
 	log.Ctx(ctx).Debug().Interface("event", event).Msg("handle gateway event")
 
-	return sqlutil.InTx(ctx, sm.db, func(tx *sql.Tx) error {
+	return inSessionTx(ctx, sm.db, sm, func(tx *sessionTx) error {
 		switch event.(type) {
+		case *janus.SessionEvent:
+			e := event.(*janus.SessionEvent)
+			switch e.Event.Name {
+			case "timeout", "destroyed":
+				if err := sm.onGatewaySessionGone(ctx, tx, &e.BaseEvent, false, e.Event.Name); err != nil {
+					return pkgerr.Wrap(err, "V1SessionManager.onGatewaySessionGone")
+				}
+			}
+		case *janus.HandleEvent:
+			e := event.(*janus.HandleEvent)
+			if e.Event.Name == "detached" && e.Event.Plugin == "janus.plugin.videoroom" {
+				if err := sm.onGatewaySessionGone(ctx, tx, &e.BaseEvent, true, e.Event.Name); err != nil {
+					return pkgerr.Wrap(err, "V1SessionManager.onGatewaySessionGone")
+				}
+			}
 		case *janus.PluginEvent:
 			e := event.(*janus.PluginEvent)
 			if e.Event.Plugin == "janus.plugin.videoroom" {
@@ -73,6 +139,10 @@ func (sm *V1SessionManager) HandleEvent(ctx context.Context, event interface{})
 					if err := sm.onVideoroomLeaving(ctx, tx, e, eventTypeStr); err != nil {
 						return pkgerr.Wrap(err, "V1SessionManager.onVideoroomLeaving")
 					}
+				case "joined", "published", "configured", "talking", "stopped-talking":
+					if err := sm.onVideoroomParticipant(ctx, tx, e, eventTypeStr); err != nil {
+						return pkgerr.Wrap(err, "V1SessionManager.onVideoroomParticipant")
+					}
 				}
 			}
 		}
@@ -90,7 +160,7 @@ func (sm *V1SessionManager) HandleProtocol(ctx context.Context, msg *janus.Textr
 		return pkgerr.WithStack(WrappingProtocolError(err, fmt.Sprintf("json.Unmarshal: %s", err.Error())))
 	}
 
-	return sqlutil.InTx(ctx, sm.db, func(tx *sql.Tx) error {
+	return inSessionTx(ctx, sm.db, sm, func(tx *sessionTx) error {
 		switch pMsg.Type {
 		case "enter":
 			if err := sm.onProtocolEnter(ctx, tx, &pMsg); err != nil {
@@ -119,8 +189,70 @@ func (sm *V1SessionManager) HandleProtocol(ctx context.Context, msg *janus.Textr
 }
 
 func (sm *V1SessionManager) UpsertSession(ctx context.Context, user *V1User) error {
-	return sqlutil.InTx(ctx, sm.db, func(tx *sql.Tx) error {
-		return sm.upsertSession(ctx, tx, user)
+	return inSessionTx(ctx, sm.db, sm, func(tx *sessionTx) error {
+		return sm.upsertSession(ctx, tx, user, common.SessionEventSourceHeartbeat)
+	})
+}
+
+func (sm *V1SessionManager) CloseSession(ctx context.Context, userID int64, source string, props map[string]interface{}) error {
+	return inSessionTx(ctx, sm.db, sm, func(tx *sessionTx) error {
+		return sm.closeSession(ctx, tx, userID, source, props)
+	})
+}
+
+// CloseSessions closes only the given sessions of the user
+func (sm *V1SessionManager) CloseSessions(ctx context.Context, userID int64, ids []int64, source string, props map[string]interface{}) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return inSessionTx(ctx, sm.db, sm, func(tx *sessionTx) error {
+		return sm.closeSessions(ctx, tx, userID, ids, source, props)
+	})
+}
+
+// ResolveQuestion marks an open question as answered or dismissed and lowers the user's question flag in the question's room.
+func (sm *V1SessionManager) ResolveQuestion(ctx context.Context, id int64, status, by string) error {
+	return inSessionTx(ctx, sm.db, sm, func(tx *sessionTx) error {
+		question, err := models.Questions(
+			models.QuestionWhere.ID.EQ(id),
+			models.QuestionWhere.Status.EQ(common.QuestionStatusOpen),
+			qm.For("update"),
+		).One(tx)
+		if err != nil {
+			return pkgerr.Wrap(err, "db fetch question")
+		}
+
+		question.Status = status
+		question.ResolvedAt = null.TimeFrom(time.Now().UTC())
+		question.ResolvedBy = null.NewString(by, by != "")
+		if _, err := question.Update(tx, boil.Whitelist(
+			models.QuestionColumns.Status,
+			models.QuestionColumns.ResolvedAt,
+			models.QuestionColumns.ResolvedBy)); err != nil {
+			return pkgerr.Wrap(err, "db update question")
+		}
+
+		sessions, err := models.Sessions(
+			models.SessionWhere.UserID.EQ(question.UserID),
+			models.SessionWhere.RoomID.EQ(null.Int64From(question.RoomID)),
+			models.SessionWhere.RemovedAt.IsNull(),
+			models.SessionWhere.Question.EQ(true),
+		).All(tx)
+		if err != nil {
+			return pkgerr.Wrap(err, "db fetch sessions")
+		}
+		for _, session := range sessions {
+			prev := *session
+			session.Question = false
+			if _, err := session.Update(tx, boil.Whitelist(models.SessionColumns.Question)); err != nil {
+				return pkgerr.Wrap(err, "db update session")
+			}
+			if err := tx.insertEvents(diffSessions(&prev, session, common.SessionEventSourceShidur)...); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 }
 
@@ -132,7 +264,7 @@ func (sm *V1SessionManager) Close() {
 	sm.cleaner.Close()
 }
 
-func (sm *V1SessionManager) onVideoroomLeaving(ctx context.Context, tx *sql.Tx, event *janus.PluginEvent, eventType string) error {
+func (sm *V1SessionManager) onVideoroomLeaving(ctx context.Context, tx *sessionTx, event *janus.PluginEvent, eventType string) error {
 	display, ok := event.Event.Data["display"].(string)
 	if !ok {
 		return nil // some service users don't set their display. ignore this event.
@@ -159,10 +291,222 @@ func (sm *V1SessionManager) onVideoroomLeaving(ctx context.Context, tx *sql.Tx,
 		return nil
 	}
 
-	return sm.closeSession(ctx, tx, userID)
+	return sm.closeSession(ctx, tx, userID, common.SessionEventSourceGateway, map[string]interface{}{"reason": eventType})
+}
+
+// onGatewaySessionGone closes the sessions of a gateway session (or handle) which is no longer there.
+func (sm *V1SessionManager) onGatewaySessionGone(ctx context.Context, tx *sessionTx, event *janus.BaseEvent, byHandle bool, reason string) error {
+	gateway, ok := sm.cache.gateways.ByName(event.Emitter)
+	if !ok {
+		log.Ctx(ctx).Warn().Msgf("core %s event from unknown gateway: %s", reason, event.Emitter)
+		return nil
+	}
+
+	b, err := json.Marshal(map[string]interface{}{
+		"close_session": time.Now().UTC(),
+		"close_reason":  reason,
+	})
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("SessionManager.onGatewaySessionGone json.Marshal")
+	}
+
+	q := "update sessions set properties = coalesce(properties, '{}'::jsonb) || $1, removed_at = $2 where gateway_id = $3 and gateway_session = $4 and removed_at is null"
+	args := []interface{}{string(b), time.Now().UTC(), gateway.ID, int64(event.Session)}
+	if byHandle {
+		q += " and gateway_handle = $5"
+		args = append(args, int64(event.Handle))
+	}
+	rows, err := queries.Raw(q+" returning id, user_id", args...).Query(tx)
+	if err != nil {
+		return pkgerr.Wrap(err, "db update sessions")
+	}
+	defer rows.Close()
+
+	propsB, _ := json.Marshal(map[string]interface{}{"reason": reason})
+	events := make([]*models.SessionEvent, 0)
+	for rows.Next() {
+		var id, userID int64
+		if err := rows.Scan(&id, &userID); err != nil {
+			return pkgerr.Wrap(err, "rows.Scan")
+		}
+		events = append(events, &models.SessionEvent{
+			SessionID:  id,
+			UserID:     userID,
+			Type:       common.SessionEventClose,
+			Source:     common.SessionEventSourceGateway,
+			Properties: null.JSONFrom(propsB),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return pkgerr.Wrap(err, "rows.Err")
+	}
+
+	if len(events) > 0 {
+		log.Ctx(ctx).Info().Msgf("%d sessions were closed on gateway %s session %d [%s]", len(events), gateway.Name, event.Session, reason)
+	}
+
+	return tx.insertEvents(events...)
 }
 
-func (sm *V1SessionManager) onProtocolEnter(ctx context.Context, tx *sql.Tx, pMsg *V1ProtocolMessageText) error {
+// onVideoroomParticipant makes the gateway an authoritative source for sessions of publishers.
+// Events carrying a display (joined) create or update the session from it,
+// a new gateway session replaces the user's sessions in that room.
+// Other events (published, configured, talking) update the session we already know of, if any.
+func (sm *V1SessionManager) onVideoroomParticipant(ctx context.Context, tx *sessionTx, event *janus.PluginEvent, eventType string) error {
+	logger := log.Ctx(ctx)
+
+	gateway, ok := sm.cache.gateways.ByName(event.Emitter)
+	if !ok {
+		logger.Warn().Msgf("videoroom %s event from unknown gateway: %s", eventType, event.Emitter)
+		return nil
+	}
+
+	session, err := models.Sessions(
+		models.SessionWhere.GatewayID.EQ(null.Int64From(gateway.ID)),
+		models.SessionWhere.GatewaySession.EQ(null.Int64From(int64(event.Session))),
+		models.SessionWhere.RemovedAt.IsNull(),
+		qm.OrderBy(fmt.Sprintf("%s desc", models.SessionColumns.CreatedAt)),
+	).One(tx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return pkgerr.Wrap(err, "db fetch session")
+		}
+		session = nil
+	}
+
+	display, ok := event.Event.Data["display"].(string)
+	if !ok || display == "" {
+		if session == nil {
+			return nil // nothing to update
+		}
+		return sm.updateSessionFromEvent(ctx, tx, session, event)
+	}
+
+	var v1User V1User
+	if err := json.Unmarshal([]byte(display), &v1User); err != nil {
+		logger.Warn().Err(err).Str("display", display).Msgf("videoroom %s event display is not a user", eventType)
+		return nil // some service users don't set their display as json. ignore this event.
+	}
+	if v1User.ID == "" {
+		return nil
+	}
+
+	// the gateway knows better than the display the client has set when joining
+	v1User.Janus = gateway.Name
+	v1User.Session = int64(event.Session)
+	v1User.Handle = int64(event.Handle)
+	if room, ok := jsonInt64(event.Event.Data["room"]); ok {
+		v1User.Room = strconv.FormatInt(room, 10)
+	}
+	if feed, ok := jsonInt64(event.Event.Data["id"]); ok {
+		v1User.RFID = strconv.FormatInt(feed, 10)
+	}
+	if session != nil {
+		// keep client state, the display is as old as the join
+		v1User.Camera = session.Camera
+		v1User.Question = session.Question
+		v1User.SelfTest = session.SelfTest
+		v1User.SoundTest = session.SoundTest
+		v1User.TextroomHandle = session.GatewayHandleTextroom.Int64
+	}
+	applyVideoroomEvent(&v1User.Camera, event)
+
+	logger.Info().Msgf("%s %s room %s [gateway]", v1User.ID, eventType, v1User.Room)
+
+	if session == nil {
+		userID, err := sm.getInternalUserID(ctx, tx, &v1User)
+		if err != nil {
+			var pErr *ProtocolError
+			if errors.As(err, &pErr) {
+				logger.Warn().Err(err).Msgf("videoroom %s event ignored", eventType)
+				return nil
+			}
+			return pkgerr.Wrap(err, "sm.getInternalUserID")
+		}
+
+		// a user rejoining the room replaces its stale session there, sessions in other rooms are left alone
+		if room, ok := sm.cache.rooms.ByGatewayUID(v1User.Room); ok {
+			sessions, err := models.Sessions(
+				qm.Select(models.SessionColumns.ID),
+				models.SessionWhere.UserID.EQ(userID),
+				models.SessionWhere.RoomID.EQ(null.Int64From(room.ID)),
+				models.SessionWhere.RemovedAt.IsNull(),
+			).All(tx)
+			if err != nil {
+				return pkgerr.Wrap(err, "db fetch room sessions")
+			}
+			if len(sessions) > 0 {
+				ids := make([]int64, len(sessions))
+				for i, stale := range sessions {
+					ids[i] = stale.ID
+				}
+				if err := sm.closeSessions(ctx, tx, userID, ids, common.SessionEventSourceGateway, map[string]interface{}{"reason": eventType}); err != nil {
+					return pkgerr.Wrap(err, "sm.closeSessions")
+				}
+			}
+		}
+	}
+
+	if err := sm.upsertSession(ctx, tx, &v1User, common.SessionEventSourceGateway); err != nil {
+		var pErr *ProtocolError
+		if errors.As(err, &pErr) {
+			logger.Warn().Err(err).Msgf("videoroom %s event ignored", eventType)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// updateSessionFromEvent applies the media state in a videoroom event to an existing session.
+// Frequent events (talking) seldom change anything, those only touch the session once in a while to keep it alive.
+func (sm *V1SessionManager) updateSessionFromEvent(ctx context.Context, tx *sessionTx, session *models.Session, event *janus.PluginEvent) error {
+	prev := *session
+	applyVideoroomEvent(&session.Camera, event)
+	if feed, ok := jsonInt64(event.Event.Data["id"]); ok {
+		session.GatewayFeed = null.Int64From(feed)
+	}
+
+	now := time.Now().UTC()
+	if session.Camera == prev.Camera && session.GatewayFeed == prev.GatewayFeed &&
+		prev.UpdatedAt.Valid && now.Sub(prev.UpdatedAt.Time) < common.Config.DeadSessionPeriod/3 {
+		return nil
+	}
+	session.UpdatedAt = null.TimeFrom(now)
+
+	_, err := session.Update(tx, boil.Whitelist(
+		models.SessionColumns.Camera,
+		models.SessionColumns.GatewayFeed,
+		models.SessionColumns.UpdatedAt))
+	if err != nil {
+		return pkgerr.Wrap(err, "db update session")
+	}
+
+	return tx.insertEvents(diffSessions(&prev, session, common.SessionEventSourceGateway)...)
+}
+
+// applyVideoroomEvent sets camera from the video_active flag of configured events
+func applyVideoroomEvent(camera *bool, event *janus.PluginEvent) {
+	if videoActive, ok := event.Event.Data["video_active"].(bool); ok {
+		*camera = videoActive
+	}
+}
+
+// jsonInt64 converts a json decoded number to int64
+func jsonInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int64(n), true
+	case json.Number:
+		i, err := n.Int64()
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func (sm *V1SessionManager) onProtocolEnter(ctx context.Context, tx *sessionTx, pMsg *V1ProtocolMessageText) error {
 	logger := log.Ctx(ctx)
 	logger.Info().Msgf("%s has enter room %d", pMsg.User.ID, pMsg.User.Room)
 
@@ -172,7 +516,7 @@ func (sm *V1SessionManager) onProtocolEnter(ctx context.Context, tx *sql.Tx, pMs
 	}
 	if userID > 0 {
 		// close existing sessions if any
-		if err := sm.closeSession(ctx, tx, userID); err != nil {
+		if err := sm.closeSession(ctx, tx, userID, common.SessionEventSourceProtocol, map[string]interface{}{"reason": pMsg.Type}); err != nil {
 			return pkgerr.Wrap(err, "sm.closeSession")
 		}
 	}
@@ -189,31 +533,36 @@ func (sm *V1SessionManager) onProtocolEnter(ctx context.Context, tx *sql.Tx, pMs
 		return pkgerr.Wrap(err, "db upsert")
 	}
 
-	return nil
+	return tx.insertEvents(diffSessions(nil, session, common.SessionEventSourceProtocol)...)
 }
 
-func (sm *V1SessionManager) onProtocolQuestion(ctx context.Context, tx *sql.Tx, pMsg *V1ProtocolMessageText) error {
+func (sm *V1SessionManager) onProtocolQuestion(ctx context.Context, tx *sessionTx, pMsg *V1ProtocolMessageText) error {
 	logger := log.Ctx(ctx)
 	logger.Info().Msgf("%s set question status to %t", pMsg.User.ID, pMsg.User.Question)
-	return sm.upsertSession(ctx, tx, &pMsg.User)
+	return sm.upsertSession(ctx, tx, &pMsg.User, common.SessionEventSourceProtocol)
 }
 
-func (sm *V1SessionManager) onProtocolCamera(ctx context.Context, tx *sql.Tx, pMsg *V1ProtocolMessageText) error {
+func (sm *V1SessionManager) onProtocolCamera(ctx context.Context, tx *sessionTx, pMsg *V1ProtocolMessageText) error {
 	logger := log.Ctx(ctx)
 	logger.Info().Msgf("%s set camera status to %t", pMsg.User.ID, pMsg.User.Camera)
-	return sm.upsertSession(ctx, tx, &pMsg.User)
+	return sm.upsertSession(ctx, tx, &pMsg.User, common.SessionEventSourceProtocol)
 }
 
-func (sm *V1SessionManager) onProtocolSoundTest(ctx context.Context, tx *sql.Tx, pMsg *V1ProtocolMessageText) error {
+func (sm *V1SessionManager) onProtocolSoundTest(ctx context.Context, tx *sessionTx, pMsg *V1ProtocolMessageText) error {
 	logger := log.Ctx(ctx)
 	logger.Info().Msgf("%s set sound-test status to %t", pMsg.User.ID, pMsg.User.SoundTest)
-	return sm.upsertSession(ctx, tx, &pMsg.User)
+	return sm.upsertSession(ctx, tx, &pMsg.User, common.SessionEventSourceProtocol)
 }
 
-func (sm *V1SessionManager) getInternalUserID(ctx context.Context, tx *sql.Tx, user *V1User) (int64, error) {
+func (sm *V1SessionManager) getInternalUserID(ctx context.Context, tx *sessionTx, user *V1User) (int64, error) {
+	claims := userClaims(ctx, user.ID)
+
 	u, ok := sm.cache.users.ByAccountsID(user.ID)
 	if ok {
-		return u.ID, nil
+		if ban, ok := sm.cache.bans.Find(u.ID, 0); ok {
+			return u.ID, NewUserBanError(user.ID, ban)
+		}
+		return u.ID, sm.syncUserProfile(tx, u, claims)
 	}
 
 	u, err := models.Users(
@@ -222,9 +571,13 @@ func (sm *V1SessionManager) getInternalUserID(ctx context.Context, tx *sql.Tx, u
 	if err == nil {
 		if u.Disabled {
 			return u.ID, NewProtocolError(fmt.Sprintf("Disabled user: %s", user.ID))
+		} else if u.RemovedAt.Valid {
+			return u.ID, NewProtocolError(fmt.Sprintf("Removed user: %s", user.ID))
+		} else if ban, ok := sm.cache.bans.Find(u.ID, 0); ok {
+			return u.ID, NewUserBanError(user.ID, ban)
 		} else {
 			sm.cache.users.Set(u)
-			return u.ID, nil
+			return u.ID, sm.syncUserProfile(tx, u, claims)
 		}
 	}
 
@@ -237,6 +590,12 @@ func (sm *V1SessionManager) getInternalUserID(ctx context.Context, tx *sql.Tx, u
 		AccountsID: user.ID,
 		Email:      null.StringFrom(user.Email),
 		Username:   null.StringFrom(user.Username),
+		LastSeenAt: null.TimeFrom(time.Now().UTC()),
+	}
+	if claims != nil {
+		if err := setUserProfile(u, claims); err != nil {
+			return 0, err
+		}
 	}
 	if err := u.Insert(tx, boil.Infer()); err != nil {
 		return 0, pkgerr.Wrap(err, "db create user")
@@ -247,7 +606,135 @@ func (sm *V1SessionManager) getInternalUserID(ctx context.Context, tx *sql.Tx, u
 	return u.ID, nil
 }
 
-func (sm *V1SessionManager) closeSession(ctx context.Context, tx *sql.Tx, userID int64) error {
+// syncUserProfile updates the user's profile from the verified token claims (if any)
+// and records when the user was last seen, at most once every userLastSeenResolution.
+// Profile claims are only written when they changed since the last sync so admin edits of the profile stick.
+// u might be a stale cached user: changes are made on the locked DB row and the cached user is dropped once committed.
+func (sm *V1SessionManager) syncUserProfile(tx *sessionTx, u *models.User, claims *middleware.IDTokenClaims) error {
+	now := time.Now().UTC()
+	syncClaims := claims != nil && !sameProfileClaims(u, claims)
+	syncLastSeen := !u.LastSeenAt.Valid || now.Sub(u.LastSeenAt.Time) >= userLastSeenResolution
+	if !syncClaims && !syncLastSeen {
+		return nil
+	}
+
+	fresh, err := models.Users(models.UserWhere.ID.EQ(u.ID), qm.For("update")).One(tx)
+	if err != nil {
+		return pkgerr.Wrap(err, "db fetch user")
+	}
+	if fresh.Disabled {
+		return NewProtocolError(fmt.Sprintf("Disabled user: %s", fresh.AccountsID))
+	} else if fresh.RemovedAt.Valid {
+		return NewProtocolError(fmt.Sprintf("Removed user: %s", fresh.AccountsID))
+	}
+
+	cols := make([]string, 0)
+	if claims != nil && !sameProfileClaims(fresh, claims) {
+		if err := setUserProfile(fresh, claims); err != nil {
+			return err
+		}
+		fresh.UpdatedAt = null.TimeFrom(now)
+		cols = append(cols,
+			models.UserColumns.FirstName,
+			models.UserColumns.LastName,
+			models.UserColumns.Email,
+			models.UserColumns.Username,
+			models.UserColumns.Properties,
+			models.UserColumns.UpdatedAt)
+	}
+	if syncLastSeen {
+		fresh.LastSeenAt = null.TimeFrom(now)
+		cols = append(cols, models.UserColumns.LastSeenAt)
+	}
+
+	if _, err := fresh.Update(tx, boil.Whitelist(cols...)); err != nil {
+		return pkgerr.Wrap(err, "db update user")
+	}
+
+	tx.afterCommit = append(tx.afterCommit, func() {
+		sm.cache.users.Remove(fresh.AccountsID)
+	})
+
+	return nil
+}
+
+// userLastSeenResolution bounds how often a user's last_seen_at is written
+const userLastSeenResolution = time.Minute
+
+// userClaims returns the verified token claims of the request in ctx, if they belong to the given user.
+func userClaims(ctx context.Context, accountsID string) *middleware.IDTokenClaims {
+	if rCtx, ok := middleware.ContextFromCtx(ctx); ok && rCtx.IDClaims != nil && rCtx.IDClaims.Sub == accountsID {
+		return rCtx.IDClaims
+	}
+	return nil
+}
+
+// userProfileClaimsProp is the user property holding the profile claims last synced onto the user
+const userProfileClaimsProp = "profile_claims"
+
+func profileClaims(claims *middleware.IDTokenClaims) map[string]string {
+	return map[string]string{
+		"given_name":         claims.GivenName,
+		"family_name":        claims.FamilyName,
+		"email":              claims.Email,
+		"preferred_username": claims.PreferredUsername,
+	}
+}
+
+// sameProfileClaims tells if claims are the profile claims last synced onto the user
+func sameProfileClaims(u *models.User, claims *middleware.IDTokenClaims) bool {
+	var props map[string]json.RawMessage
+	if !u.Properties.Valid || u.Properties.Unmarshal(&props) != nil {
+		return false
+	}
+	var synced map[string]string
+	if err := json.Unmarshal(props[userProfileClaimsProp], &synced); err != nil {
+		return false
+	}
+	for k, v := range profileClaims(claims) {
+		if synced[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// setUserProfile copies non empty profile claims onto the user and records them as synced in its properties.
+func setUserProfile(u *models.User, claims *middleware.IDTokenClaims) error {
+	setNullString(&u.FirstName, claims.GivenName)
+	setNullString(&u.LastName, claims.FamilyName)
+	setNullString(&u.Email, claims.Email)
+	setNullString(&u.Username, claims.PreferredUsername)
+
+	props := make(map[string]interface{})
+	if u.Properties.Valid {
+		if err := u.Properties.Unmarshal(&props); err != nil {
+			return pkgerr.Wrap(err, "json.Unmarshal user properties")
+		}
+	}
+	props[userProfileClaimsProp] = profileClaims(claims)
+	b, err := json.Marshal(props)
+	if err != nil {
+		return pkgerr.Wrap(err, "json.Marshal user properties")
+	}
+	u.Properties = null.JSONFrom(b)
+	return nil
+}
+
+func setNullString(field *null.String, value string) bool {
+	if value == "" || (field.Valid && field.String == value) {
+		return false
+	}
+	*field = null.StringFrom(value)
+	return true
+}
+
+func (sm *V1SessionManager) closeSession(ctx context.Context, tx *sessionTx, userID int64, source string, props map[string]interface{}) error {
+	return sm.closeSessions(ctx, tx, userID, nil, source, props)
+}
+
+// closeSessions closes the active sessions of the user, only those in ids unless it's nil
+func (sm *V1SessionManager) closeSessions(ctx context.Context, tx *sessionTx, userID int64, ids []int64, source string, props map[string]interface{}) error {
 	b, err := json.Marshal(map[string]interface{}{
 		"close_session": time.Now().UTC(),
 	})
@@ -255,23 +742,53 @@ func (sm *V1SessionManager) closeSession(ctx context.Context, tx *sql.Tx, userID
 		log.Ctx(ctx).Error().Err(err).Msg("SessionManager.closeSession json.Marshal")
 	}
 
-	res, err := queries.Raw("update sessions set properties = coalesce(properties, '{}'::jsonb) || $1, removed_at = $2 where user_id = $3 and removed_at is null",
-		string(b), time.Now().UTC(), userID,
-	).Exec(tx)
+	query := "update sessions set properties = coalesce(properties, '{}'::jsonb) || $1, removed_at = $2 where user_id = $3 and removed_at is null"
+	args := []interface{}{string(b), time.Now().UTC(), userID}
+	if ids != nil {
+		query += " and id = ANY($4)"
+		args = append(args, pq.Array(ids))
+	}
+	rows, err := queries.Raw(query+" returning id", args...).Query(tx)
 	if err != nil {
 		return pkgerr.Wrap(err, "db update session")
 	}
+	defer rows.Close()
+
+	closed := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return pkgerr.Wrap(err, "rows.Scan")
+		}
+		closed = append(closed, id)
+	}
+	if err := rows.Err(); err != nil {
+		return pkgerr.Wrap(err, "rows.Err")
+	}
+	log.Ctx(ctx).Info().Msgf("%d sessions were closed", len(closed))
 
-	rowsAffected, err := res.RowsAffected()
+	propsB, err := json.Marshal(props)
 	if err != nil {
-		return pkgerr.Wrap(err, "db update session")
+		log.Ctx(ctx).Error().Err(err).Msg("SessionManager.closeSession json.Marshal")
+	}
+
+	for _, id := range closed {
+		event := &models.SessionEvent{
+			SessionID:  id,
+			UserID:     userID,
+			Type:       common.SessionEventClose,
+			Source:     source,
+			Properties: null.JSONFrom(propsB),
+		}
+		if err := tx.insertEvents(event); err != nil {
+			return err
+		}
 	}
-	log.Ctx(ctx).Info().Msgf("%d sessions were closed", rowsAffected)
 
 	return nil
 }
 
-func (sm *V1SessionManager) upsertSession(ctx context.Context, tx *sql.Tx, user *V1User) error {
+func (sm *V1SessionManager) upsertSession(ctx context.Context, tx *sessionTx, user *V1User, source string) error {
 	userID, err := sm.getInternalUserID(ctx, tx, user)
 	if err != nil {
 		return pkgerr.Wrap(err, "sm.getInternalUserID")
@@ -282,6 +799,19 @@ func (sm *V1SessionManager) upsertSession(ctx context.Context, tx *sql.Tx, user
 		return pkgerr.Wrap(err, "sm.makeSession")
 	}
 
+	// fetch current state so we could record what has changed
+	prev, err := models.Sessions(
+		models.SessionWhere.UserID.EQ(session.UserID),
+		models.SessionWhere.GatewayID.EQ(session.GatewayID),
+		models.SessionWhere.GatewaySession.EQ(session.GatewaySession),
+	).One(tx)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return pkgerr.Wrap(err, "db fetch session")
+		}
+		prev = nil
+	}
+
 	err = session.Upsert(tx, true,
 		[]string{models.SessionColumns.UserID, models.SessionColumns.GatewayID, models.SessionColumns.GatewaySession},
 		boil.Blacklist(models.SessionColumns.CreatedAt, models.SessionColumns.Properties), boil.Infer())
@@ -289,7 +819,58 @@ func (sm *V1SessionManager) upsertSession(ctx context.Context, tx *sql.Tx, user
 		return pkgerr.Wrap(err, "db upsert")
 	}
 
-	return nil
+	return tx.insertEvents(diffSessions(prev, session, source)...)
+}
+
+func newSessionEvent(session *models.Session, eType, source string) *models.SessionEvent {
+	return &models.SessionEvent{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Type:      eType,
+		Source:    source,
+	}
+}
+
+// diffSessions returns the lifecycle events leading from prev to session.
+// A nil prev means the session is new.
+func diffSessions(prev, session *models.Session, source string) []*models.SessionEvent {
+	events := make([]*models.SessionEvent, 0)
+
+	if prev == nil {
+		event := newSessionEvent(session, common.SessionEventEnter, source)
+		event.NewValue = null.StringFrom(strconv.FormatInt(session.RoomID.Int64, 10))
+		return append(events, event)
+	}
+
+	if prev.RemovedAt.Valid {
+		events = append(events, newSessionEvent(session, common.SessionEventRevive, source))
+	}
+
+	if prev.RoomID != session.RoomID {
+		event := newSessionEvent(session, common.SessionEventRoom, source)
+		event.OldValue = null.StringFrom(strconv.FormatInt(prev.RoomID.Int64, 10))
+		event.NewValue = null.StringFrom(strconv.FormatInt(session.RoomID.Int64, 10))
+		events = append(events, event)
+	}
+
+	flags := []struct {
+		eType    string
+		old, new bool
+	}{
+		{common.SessionEventQuestion, prev.Question, session.Question},
+		{common.SessionEventCamera, prev.Camera, session.Camera},
+		{common.SessionEventSoundTest, prev.SoundTest, session.SoundTest},
+	}
+	for _, flag := range flags {
+		if flag.old != flag.new {
+			event := newSessionEvent(session, flag.eType, source)
+			event.OldValue = null.StringFrom(strconv.FormatBool(flag.old))
+			event.NewValue = null.StringFrom(strconv.FormatBool(flag.new))
+			events = append(events, event)
+		}
+	}
+
+	return events
 }
 
 type ProtocolError struct {
@@ -309,6 +890,25 @@ func WrappingProtocolError(err error, msg string) *ProtocolError {
 	}}
 }
 
+// UserBanError is the ProtocolError of a banned user trying to get in.
+// Its message is returned to the client.
+type UserBanError struct {
+	*ProtocolError
+	Ban *models.UserBan
+}
+
+func NewUserBanError(accountsID string, ban *models.UserBan) *UserBanError {
+	msg := fmt.Sprintf("Banned user: %s is banned until %s", accountsID, ban.Until.Format(time.RFC3339))
+	if ban.Reason.Valid && ban.Reason.String != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, ban.Reason.String)
+	}
+	return &UserBanError{ProtocolError: NewProtocolError(msg), Ban: ban}
+}
+
+func (e *UserBanError) Unwrap() error {
+	return e.ProtocolError
+}
+
 func (sm *V1SessionManager) makeSession(userID int64, user *V1User) (*models.Session, error) {
 	room, ok := sm.cache.rooms.ByGatewayUID(user.Room)
 	if !ok {
@@ -320,6 +920,10 @@ func (sm *V1SessionManager) makeSession(userID int64, user *V1User) (*models.Ses
 		return nil, NewProtocolError(fmt.Sprintf("Unknown gateway: %s", user.Janus))
 	}
 
+	if ban, ok := sm.cache.bans.Find(userID, room.ID); ok {
+		return nil, NewUserBanError(user.ID, ban)
+	}
+
 	s := models.Session{
 		UserID:                userID,
 		RoomID:                null.Int64From(room.ID),
@@ -349,12 +953,16 @@ func (sm *V1SessionManager) makeSession(userID int64, user *V1User) (*models.Ses
 }
 
 type PeriodicSessionCleaner struct {
+	*patterns.SimpleObservable
 	ticker *time.Ticker
 	db     common.DBInterface
 }
 
 func NewPeriodicSessionCleaner(db common.DBInterface) *PeriodicSessionCleaner {
-	return &PeriodicSessionCleaner{db: db}
+	return &PeriodicSessionCleaner{
+		SimpleObservable: patterns.NewSimpleObservable(),
+		db:               db,
+	}
 }
 
 func (psc *PeriodicSessionCleaner) Start() {
@@ -406,7 +1014,7 @@ func (psc *PeriodicSessionCleaner) clean() {
 	if err != nil {
 		log.Error().Err(err).Msg("PeriodicSessionCleaner json.Marshal")
 	}
-	err = sqlutil.InTx(context.TODO(), psc.db, func(tx *sql.Tx) error {
+	err = inSessionTx(context.TODO(), psc.db, psc, func(tx *sessionTx) error {
 		ids := make([]int64, len(sessions))
 		for i := range sessions {
 			ids[i] = sessions[i].ID
@@ -430,6 +1038,14 @@ func (psc *PeriodicSessionCleaner) clean() {
 			return nil
 		}
 
+		for _, session := range sessions {
+			event := newSessionEvent(session, common.SessionEventClean, common.SessionEventSourceCleaner)
+			event.OldValue = null.StringFrom(session.UpdatedAt.Time.UTC().Format(time.RFC3339))
+			if err := tx.insertEvents(event); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 
@@ -1,14 +1,19 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/volatiletech/null"
 	"github.com/volatiletech/sqlboiler/boil"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/middleware"
 	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/stringutil"
 )
 
 func (s *ApiTestSuite) TestSessions_Clean() {
@@ -55,4 +60,100 @@ func (s *ApiTestSuite) TestSessions_Clean() {
 	s.Require().NoError(sessions[1].Properties.Unmarshal(&props))
 	s.NotNil(props["clean_session"], "session[1] clean_session property")
 	s.NotNil(props["close_session"], "session[1] should keep close_session property")
+
+	events, err := models.SessionEvents().All(s.DB)
+	s.Require().NoError(err, "fetch session events")
+	s.Require().Len(events, 2, "len(events)")
+	for _, event := range events {
+		s.Equal(common.SessionEventClean, event.Type, "event type")
+		s.Equal(common.SessionEventSourceCleaner, event.Source, "event source")
+	}
+}
+
+func (s *ApiTestSuite) TestSessions_SyncUserProfile() {
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	s.Require().NoError(s.app.cache.ReloadAll(s.DB))
+
+	// no claims, only last seen
+	v1User := s.makeV1user(gateway, room, user)
+	s.Require().NoError(s.app.sessionManager.UpsertSession(context.TODO(), v1User))
+	s.Require().NoError(user.Reload(s.DB))
+	s.True(user.LastSeenAt.Valid, "last_seen_at")
+	s.Equal("first", user.FirstName.String, "first_name")
+
+	// claims of another user are ignored
+	ctx, rCtx := s.requestCtx()
+	rCtx.IDClaims = &middleware.IDTokenClaims{Sub: "other", GivenName: "other"}
+	s.Require().NoError(s.app.sessionManager.UpsertSession(ctx, v1User))
+	s.Require().NoError(user.Reload(s.DB))
+	s.Equal("first", user.FirstName.String, "first_name")
+
+	rCtx.IDClaims = &middleware.IDTokenClaims{
+		Sub:               user.AccountsID,
+		GivenName:         "given",
+		FamilyName:        "family",
+		Email:             "given@example.com",
+		PreferredUsername: "given.family",
+	}
+	s.Require().NoError(s.app.sessionManager.UpsertSession(ctx, v1User))
+	s.Require().NoError(user.Reload(s.DB))
+	s.Equal("given", user.FirstName.String, "first_name")
+	s.Equal("family", user.LastName.String, "last_name")
+	s.Equal("given@example.com", user.Email.String, "email")
+	s.Equal("given.family", user.Username.String, "username")
+	s.True(user.UpdatedAt.Valid, "updated_at")
+
+	_, ok := s.app.cache.users.ByAccountsID(user.AccountsID)
+	s.False(ok, "dropped from cache once synced")
+
+	// admin edits stick as long as the claims don't change
+	user.FirstName = null.StringFrom("edited")
+	_, err := user.Update(s.DB, boil.Whitelist(models.UserColumns.FirstName))
+	s.Require().NoError(err)
+	s.Require().NoError(s.app.sessionManager.UpsertSession(ctx, v1User))
+	s.Require().NoError(user.Reload(s.DB))
+	s.Equal("edited", user.FirstName.String, "edited first_name")
+	cached, ok := s.app.cache.users.ByAccountsID(user.AccountsID)
+	s.Require().True(ok, "cached")
+	s.Equal("edited", cached.FirstName.String, "cached first_name")
+
+	rCtx.IDClaims.GivenName = "renamed"
+	s.Require().NoError(s.app.sessionManager.UpsertSession(ctx, v1User))
+	s.Require().NoError(user.Reload(s.DB))
+	s.Equal("renamed", user.FirstName.String, "changed claims first_name")
+
+	// a stale cached user disabled meanwhile isn't updated
+	s.Require().NoError(s.app.sessionManager.UpsertSession(ctx, v1User))
+	_, ok = s.app.cache.users.ByAccountsID(user.AccountsID)
+	s.Require().True(ok, "cached again")
+	user.Disabled = true
+	_, err = user.Update(s.DB, boil.Whitelist(models.UserColumns.Disabled))
+	s.Require().NoError(err)
+	rCtx.IDClaims.GivenName = "disabled"
+	s.Error(s.app.sessionManager.UpsertSession(ctx, v1User), "disabled user")
+	s.Require().NoError(user.Reload(s.DB))
+	s.Equal("renamed", user.FirstName.String, "disabled user first_name")
+
+	// new user created from claims
+	newUser := &models.User{AccountsID: stringutil.GenerateName(36)}
+	v1User = s.makeV1user(gateway, room, newUser)
+	rCtx.IDClaims = &middleware.IDTokenClaims{Sub: newUser.AccountsID, GivenName: "new", FamilyName: "user"}
+	s.Require().NoError(s.app.sessionManager.UpsertSession(ctx, v1User))
+	newUser, err = models.Users(models.UserWhere.AccountsID.EQ(newUser.AccountsID)).One(s.DB)
+	s.Require().NoError(err)
+	s.Equal("new", newUser.FirstName.String, "first_name")
+	s.Equal("user", newUser.LastName.String, "last_name")
+	s.True(newUser.LastSeenAt.Valid, "last_seen_at")
+}
+
+// requestCtx returns a context carrying a request context, as set by the context middleware
+func (s *ApiTestSuite) requestCtx() (context.Context, *middleware.RequestContext) {
+	var ctx context.Context
+	middleware.ContextMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	rCtx, _ := middleware.ContextFromCtx(ctx)
+	return ctx, rCtx
 }
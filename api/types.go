@@ -3,19 +3,31 @@ package api
 import "time"
 
 type V2Gateway struct {
-	Name  string `json:"name"`
-	URL   string `json:"url"`
-	Type  string `json:"type"`
-	Token string `json:"token"`
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	Type      string `json:"type"`
+	Token     string `json:"token"`
+	Unhealthy bool   `json:"unhealthy,omitempty"`
 }
 
 type V2Config struct {
 	Gateways      map[string]map[string]*V2Gateway `json:"gateways"`
 	IceServers    map[string][]string              `json:"ice_servers"`
 	DynamicConfig map[string]string                `json:"dynamic_config"`
+	Mountpoints   []*V2Mountpoint                  `json:"mountpoints"`
 	LastModified  time.Time                        `json:"last_modified"`
 }
 
+// V2Mountpoint is a streaming plugin mountpoint available on all streaming gateways
+type V2Mountpoint struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Audio       bool   `json:"audio"`
+	Video       bool   `json:"video"`
+	Data        bool   `json:"data"`
+}
+
 type V2RoomStatistics struct {
 	OnAir int `json:"on_air"`
 }
@@ -76,6 +88,17 @@ type V1ProtocolMessageText struct {
 	User   V1User
 }
 
+type V1EventsBatchResult struct {
+	Total     int               `json:"total"`
+	Processed int               `json:"processed"`
+	Failed    []*V1EventFailure `json:"failed"`
+}
+
+type V1EventFailure struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
 type V1ServiceProtocolMessageText struct {
 	Type        string
 	Status      bool
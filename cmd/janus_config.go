@@ -1,10 +1,10 @@
 package cmd
 
 import (
-	"database/sql"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	pkgerr "github.com/pkg/errors"
@@ -13,6 +13,7 @@ import (
 	"github.com/volatiletech/sqlboiler/queries/qm"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/domain"
 	"github.com/Bnei-Baruch/gxydb-api/models"
 )
 
@@ -30,13 +31,7 @@ func janusConfigFn(cmd *cobra.Command, args []string) {
 	log.Info().Msg("Generating janus config files for rooms gateways")
 
 	// init db conn
-	db, err := sql.Open("postgres", common.Config.DBUrl)
-	if err != nil {
-		log.Fatal().Err(err).Msg("sql.Open")
-	}
-	db.SetMaxIdleConns(common.Config.DBMaxIdleConns)
-	db.SetMaxOpenConns(common.Config.DBMaxOpenConns)
-	db.SetConnMaxLifetime(common.Config.DBConnMaxLifetime)
+	db := openDB()
 
 	// open files for writing
 	videoroomFile, err := os.Create("janus.plugin.videoroom.jcfg")
@@ -61,41 +56,29 @@ func janusConfigFn(cmd *cobra.Command, args []string) {
 		log.Fatal().Err(err).Msg("fetch rooms from db")
 	}
 
+	profiles, err := domain.LoadRoomProfiles(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("fetch room profiles from db")
+	}
+
 	log.Info().Msgf("got %d rooms from DB", len(rooms))
 	for _, room := range rooms {
-		_, err := fmt.Fprintf(videoroomFile,
-			`room-%d : 
-{
-  description = "%s";
-  bitrate = "64000";
-  publishers = "100";
-  fir_freq = "10";
-  audiocodec = "opus";
-  videocodec = "h264";
-  h264_profile = "42e01f";
-  opus_fec = "yes";
-  secret = "%s";
-  audiolevel_ext = "yes";
-  audiolevel_event = "yes";
-  audio_active_packets = "25";
-  audio_level_average = "100";
-  videoorient_ext = "yes";
-  playoutdelay_ext = "yes";
-  transport_wide_cc_ext = "yes";
-};
-`, room.GatewayUID, room.Name, common.Config.GatewayRoomsSecret,
-		)
+		err := writeVideoroom(videoroomFile, room, profiles.For(room))
 		if err != nil {
 			log.Fatal().Err(err).Msgf("write room to videoroom config %d", room.ID)
 		}
 
+		post := ""
+		if common.Config.TextroomPostURL != "" {
+			post = fmt.Sprintf("  post = \"%s\";\n", common.Config.TextroomPostURL)
+		}
 		_, err = fmt.Fprintf(textroomFile,
 			`room-%d : 
 {
   description = "%s";
   secret = "%s";
-};
-`, room.GatewayUID, room.Name, common.Config.GatewayRoomsSecret,
+%s};
+`, room.GatewayUID, room.Name, common.Config.GatewayRoomsSecret, post,
 		)
 		if err != nil {
 			log.Fatal().Err(err).Msgf("write room to textroom config %d", room.ID)
@@ -105,6 +88,50 @@ func janusConfigFn(cmd *cobra.Command, args []string) {
 	log.Info().Msg("All done. Don't forget to change secrets in textroom config files")
 }
 
+func writeVideoroom(w io.Writer, room *models.Room, profile *models.RoomProfile) error {
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+
+	var optional strings.Builder
+	if profile.H264Profile.Valid {
+		fmt.Fprintf(&optional, "  h264_profile = \"%s\";\n", profile.H264Profile.String)
+	}
+	if profile.AudioActivePackets.Valid {
+		fmt.Fprintf(&optional, "  audio_active_packets = \"%d\";\n", profile.AudioActivePackets.Int)
+	}
+	if profile.AudioLevelAverage.Valid {
+		fmt.Fprintf(&optional, "  audio_level_average = \"%d\";\n", profile.AudioLevelAverage.Int)
+	}
+
+	_, err := fmt.Fprintf(w,
+		`room-%d : 
+{
+  description = "%s";
+  bitrate = "%d";
+  publishers = "%d";
+  fir_freq = "%d";
+  audiocodec = "%s";
+  videocodec = "%s";
+  opus_fec = "%s";
+  secret = "%s";
+  audiolevel_ext = "%s";
+  audiolevel_event = "%s";
+  videoorient_ext = "%s";
+  playoutdelay_ext = "%s";
+  transport_wide_cc_ext = "%s";
+%s};
+`, room.GatewayUID, room.Name, profile.Bitrate, profile.Publishers, profile.FirFreq,
+		profile.Audiocodec, profile.Videocodec, yesNo(profile.OpusFec), common.Config.GatewayRoomsSecret,
+		yesNo(profile.AudiolevelExt), yesNo(profile.AudiolevelEvent), yesNo(profile.VideoorientExt),
+		yesNo(profile.PlayoutdelayExt), yesNo(profile.TransportWideCCExt), optional.String(),
+	)
+	return err
+}
+
 func writeStaticConfig(videoroomFile, textroomFile io.Writer) error {
 	// head comment
 	_, err := fmt.Fprintf(videoroomFile, "#\n# File automatically generated by gxydb-api on %s \n#",
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/Bnei-Baruch/gxydb-api/domain"
+)
+
+var syncRoomsDryRun bool
+
+var syncRoomsCmd = &cobra.Command{
+	Use:   "sync-rooms",
+	Short: "Reconcile videorooms and textrooms on rooms gateways with the rooms table",
+	Long: `Reconcile videorooms and textrooms on rooms gateways with the rooms table.
+Missing rooms are created, rooms with drifted settings are edited and removed rooms are destroyed.
+Rooms unknown to the DB are reported but left untouched.
+The report is printed as JSON.`,
+	Run: syncRoomsFn,
+}
+
+func init() {
+	syncRoomsCmd.Flags().BoolVar(&syncRoomsDryRun, "dry-run", false, "report differences without changing gateways")
+	rootCmd.AddCommand(syncRoomsCmd)
+}
+
+func syncRoomsFn(cmd *cobra.Command, args []string) {
+	report, err := domain.NewRoomsSyncer(openDB()).Sync(syncRoomsDryRun)
+	if err != nil {
+		log.Fatal().Err(err).Msg("sync rooms")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatal().Err(err).Msg("json encode report")
+	}
+
+	if failed := report.Failed(); failed > 0 {
+		log.Fatal().Msgf("%d gateways or actions failed", failed)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/domain"
+)
+
+var userDataCmd = &cobra.Command{
+	Use:   "user-data",
+	Short: "Export or erase the data held about a user (privacy requests)",
+}
+
+var userDataExportCmd = &cobra.Command{
+	Use:   "export <accounts_id>",
+	Short: "Print everything held about a user as JSON",
+	Args:  cobra.ExactArgs(1),
+	Run:   userDataExportFn,
+}
+
+var userDataEraseCmd = &cobra.Command{
+	Use:   "erase <accounts_id>",
+	Short: "Anonymize a user while keeping aggregate counts intact",
+	Long: `Anonymize a user while keeping aggregate counts intact.
+Running servers may keep the user in their cache for a while.
+Prefer POST /admin/users/{accounts_id}/erase when the API is up.`,
+	Args: cobra.ExactArgs(1),
+	Run:  userDataEraseFn,
+}
+
+func init() {
+	userDataCmd.AddCommand(userDataExportCmd)
+	userDataCmd.AddCommand(userDataEraseCmd)
+	rootCmd.AddCommand(userDataCmd)
+}
+
+func userDataExportFn(cmd *cobra.Command, args []string) {
+	data, err := domain.NewUserDataManager(openDB()).Export(args[0])
+	if err != nil {
+		log.Fatal().Err(err).Msg("export user data")
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		log.Fatal().Err(err).Msg("json encode user data")
+	}
+}
+
+func userDataEraseFn(cmd *cobra.Command, args []string) {
+	user, err := domain.NewUserDataManager(openDB()).Erase(context.Background(), args[0])
+	if err != nil {
+		log.Fatal().Err(err).Msg("erase user data")
+	}
+
+	log.Info().Msgf("user %s erased, data moved to anonymous user %d", args[0], user.ID)
+}
+
+func openDB() *sql.DB {
+	db, err := sql.Open("postgres", common.Config.DBUrl)
+	if err != nil {
+		log.Fatal().Err(err).Msg("sql.Open")
+	}
+	db.SetMaxIdleConns(common.Config.DBMaxIdleConns)
+	db.SetMaxOpenConns(common.Config.DBMaxOpenConns)
+	db.SetConnMaxLifetime(common.Config.DBConnMaxLifetime)
+	return db
+}
@@ -21,9 +21,16 @@ type config struct {
 	MonitorGatewayTokens  bool
 	GatewayRoomsSecret    string
 	GatewayPluginAdminKey string
+	TextroomPostURL       string
+	MountpointsSecret     string
 	CollectPeriodicStats  bool
 	CleanSessionsInterval time.Duration
 	DeadSessionPeriod     time.Duration
+	ReconcileInterval     time.Duration
+	EventWorkers          int
+	GatewayFailureLimit   int
+	AttendanceInterval    time.Duration
+	RoomsSyncInterval     time.Duration
 	DBMaxIdleConns        int
 	DBMaxOpenConns        int
 	DBConnMaxLifetime     time.Duration
@@ -46,9 +53,16 @@ func newConfig() *config {
 		MonitorGatewayTokens:  true,
 		GatewayRoomsSecret:    "",
 		GatewayPluginAdminKey: "",
+		TextroomPostURL:       "",
+		MountpointsSecret:     "",
 		CollectPeriodicStats:  true,
 		CleanSessionsInterval: time.Minute,
 		DeadSessionPeriod:     90 * time.Second,
+		ReconcileInterval:     time.Minute,
+		EventWorkers:          16,
+		GatewayFailureLimit:   3,
+		AttendanceInterval:    time.Hour,
+		RoomsSyncInterval:     0,
 		DBMaxIdleConns:        2,
 		DBMaxOpenConns:        0,
 		DBConnMaxLifetime:     0,
@@ -103,6 +117,12 @@ func Init() {
 	if val := os.Getenv("GATEWAY_PLUGIN_ADMIN_KEY"); val != "" {
 		Config.GatewayPluginAdminKey = val
 	}
+	if val := os.Getenv("TEXTROOM_POST_URL"); val != "" {
+		Config.TextroomPostURL = val
+	}
+	if val := os.Getenv("MOUNTPOINTS_SECRET"); val != "" {
+		Config.MountpointsSecret = val
+	}
 	if val := os.Getenv("COLLECT_PERIODIC_STATS"); val != "" {
 		Config.CollectPeriodicStats = val == "true"
 	}
@@ -123,6 +143,47 @@ func Init() {
 		}
 		Config.DeadSessionPeriod = pVal
 	}
+	if val := os.Getenv("RECONCILE_INTERVAL"); val != "" {
+		pVal, err := time.ParseDuration(val)
+		if err != nil {
+			panic(err)
+		}
+		Config.ReconcileInterval = pVal
+	}
+	if val := os.Getenv("ATTENDANCE_INTERVAL"); val != "" {
+		pVal, err := time.ParseDuration(val)
+		if err != nil {
+			panic(err)
+		}
+		Config.AttendanceInterval = pVal
+	}
+	if val := os.Getenv("ROOMS_SYNC_INTERVAL"); val != "" {
+		pVal, err := time.ParseDuration(val)
+		if err != nil {
+			panic(err)
+		}
+		Config.RoomsSyncInterval = pVal
+	}
+	if val := os.Getenv("EVENT_WORKERS"); val != "" {
+		pVal, err := strconv.Atoi(val)
+		if err != nil {
+			panic(err)
+		}
+		if pVal <= 0 {
+			panic(fmt.Errorf("EVENT_WORKERS must be positive, got %d", pVal))
+		}
+		Config.EventWorkers = pVal
+	}
+	if val := os.Getenv("GATEWAY_FAILURE_LIMIT"); val != "" {
+		pVal, err := strconv.Atoi(val)
+		if err != nil {
+			panic(err)
+		}
+		if pVal <= 0 {
+			panic(fmt.Errorf("GATEWAY_FAILURE_LIMIT must be positive, got %d", pVal))
+		}
+		Config.GatewayFailureLimit = pVal
+	}
 	if val := os.Getenv("DB_MAX_IDLE_CONNS"); val != "" {
 		pVal, err := strconv.Atoi(val)
 		if err != nil {
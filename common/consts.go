@@ -19,3 +19,25 @@ const APIDefaultPageSize = 50
 const APIMaxPageSize = 1000
 
 const DynamicConfigMQTTAuth = "mqtt_auth"
+
+const SessionEventEnter = "enter"
+const SessionEventClose = "close"
+const SessionEventClean = "clean"
+const SessionEventRevive = "revive"
+const SessionEventRoom = "room"
+const SessionEventQuestion = "question"
+const SessionEventCamera = "camera"
+const SessionEventSoundTest = "sound_test"
+
+const SessionEventSourceProtocol = "protocol"
+const SessionEventSourceGateway = "gateway"
+const SessionEventSourceHeartbeat = "heartbeat"
+const SessionEventSourceCleaner = "cleaner"
+const SessionEventSourceAdmin = "admin"
+const SessionEventSourceReconciler = "reconciler"
+const SessionEventSourceShidur = "shidur"
+
+const QuestionStatusOpen = "open"
+const QuestionStatusAnswered = "answered"
+const QuestionStatusDismissed = "dismissed"
+const QuestionStatusWithdrawn = "withdrawn"
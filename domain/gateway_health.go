@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"sync"
+	"time"
+
+	"github.com/volatiletech/null"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+var GatewayHealthRegistry = NewGatewayHealthRegistry()
+
+// GatewayHealth is the outcome of probing a gateway's admin API
+type GatewayHealth struct {
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastSuccess         null.Time `json:"last_success,omitempty"`
+	LastFailure         null.Time `json:"last_failure,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+	LatencyMS           int64     `json:"latency_ms"`
+	Sessions            int       `json:"sessions"`
+	sessionIDs          []uint64
+	sessionIDsAt        time.Time
+}
+
+// gatewayHealthRegistry keeps the health of gateways by their ID.
+// A gateway becomes unhealthy after common.Config.GatewayFailureLimit consecutive failed probes
+// and healthy again on the first successful one. Gateways never probed are considered healthy.
+type gatewayHealthRegistry struct {
+	lock     sync.RWMutex
+	registry map[int64]*GatewayHealth
+}
+
+func NewGatewayHealthRegistry() *gatewayHealthRegistry {
+	return &gatewayHealthRegistry{
+		registry: make(map[int64]*GatewayHealth),
+	}
+}
+
+// ReportSuccess records a successful probe which listed the given gateway sessions
+func (r *gatewayHealthRegistry) ReportSuccess(gateway *models.Gateway, latency time.Duration, sessions []uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	h := r.getOrCreate(gateway)
+	h.Healthy = true
+	h.ConsecutiveFailures = 0
+	h.LastSuccess = null.TimeFrom(time.Now().UTC())
+	h.LatencyMS = latency.Milliseconds()
+	h.Sessions = len(sessions)
+	h.sessionIDs = sessions
+	h.sessionIDsAt = time.Now().UTC().Add(-latency)
+}
+
+func (r *gatewayHealthRegistry) ReportFailure(gateway *models.Gateway, latency time.Duration, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	h := r.getOrCreate(gateway)
+	h.ConsecutiveFailures++
+	h.Healthy = h.ConsecutiveFailures < common.Config.GatewayFailureLimit
+	h.LastFailure = null.TimeFrom(time.Now().UTC())
+	h.LatencyMS = latency.Milliseconds()
+	if err != nil {
+		h.LastError = err.Error()
+	}
+}
+
+// Get returns a copy of the gateway's health, if it was ever probed
+func (r *gatewayHealthRegistry) Get(gateway *models.Gateway) (GatewayHealth, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if h, ok := r.registry[gateway.ID]; ok {
+		return *h, true
+	}
+	return GatewayHealth{}, false
+}
+
+// SessionIDs returns the gateway sessions listed by the last successful probe and when that probe started
+func (r *gatewayHealthRegistry) SessionIDs(gateway *models.Gateway) ([]uint64, time.Time, bool) {
+	h, ok := r.Get(gateway)
+	if !ok || !h.LastSuccess.Valid {
+		return nil, time.Time{}, false
+	}
+	return h.sessionIDs, h.sessionIDsAt, true
+}
+
+func (r *gatewayHealthRegistry) IsHealthy(gateway *models.Gateway) bool {
+	h, ok := r.Get(gateway)
+	return !ok || h.Healthy
+}
+
+func (r *gatewayHealthRegistry) Remove(gateway *models.Gateway) {
+	r.lock.Lock()
+	delete(r.registry, gateway.ID)
+	r.lock.Unlock()
+}
+
+func (r *gatewayHealthRegistry) getOrCreate(gateway *models.Gateway) *GatewayHealth {
+	h, ok := r.registry[gateway.ID]
+	if !ok {
+		h = &GatewayHealth{Healthy: true}
+		r.registry[gateway.ID] = h
+	}
+	return h
+}
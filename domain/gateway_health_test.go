@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+func TestGatewayHealthRegistry(t *testing.T) {
+	common.Config.GatewayFailureLimit = 3
+	r := NewGatewayHealthRegistry()
+	gateway := &models.Gateway{ID: 1, Name: "gateway"}
+
+	_, ok := r.Get(gateway)
+	assert.False(t, ok, "never probed")
+	assert.True(t, r.IsHealthy(gateway), "never probed is healthy")
+	_, _, ok = r.SessionIDs(gateway)
+	assert.False(t, ok, "never probed session ids")
+
+	r.ReportSuccess(gateway, 10*time.Millisecond, []uint64{1, 2, 3, 4, 5})
+	h, ok := r.Get(gateway)
+	assert.True(t, ok, "probed")
+	assert.True(t, h.Healthy, "healthy")
+	assert.True(t, h.LastSuccess.Valid, "last success")
+	assert.Equal(t, int64(10), h.LatencyMS, "latency")
+	assert.Equal(t, 5, h.Sessions, "sessions")
+	ids, probedAt, ok := r.SessionIDs(gateway)
+	assert.True(t, ok, "session ids")
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, ids, "session ids")
+	assert.False(t, probedAt.After(h.LastSuccess.Time), "probed at")
+
+	for i := 1; i < common.Config.GatewayFailureLimit; i++ {
+		r.ReportFailure(gateway, time.Millisecond, errors.New("boom"))
+		assert.Truef(t, r.IsHealthy(gateway), "healthy after %d failures", i)
+	}
+	r.ReportFailure(gateway, time.Millisecond, errors.New("boom"))
+	h, _ = r.Get(gateway)
+	assert.False(t, h.Healthy, "unhealthy after limit")
+	assert.Equal(t, common.Config.GatewayFailureLimit, h.ConsecutiveFailures, "consecutive failures")
+	assert.Equal(t, "boom", h.LastError, "last error")
+	assert.True(t, h.LastFailure.Valid, "last failure")
+
+	r.ReportSuccess(gateway, time.Millisecond, nil)
+	h, _ = r.Get(gateway)
+	assert.True(t, h.Healthy, "healthy after success")
+	assert.Zero(t, h.ConsecutiveFailures, "consecutive failures reset")
+
+	r.Remove(gateway)
+	_, ok = r.Get(gateway)
+	assert.False(t, ok, "removed")
+}
@@ -1,8 +1,11 @@
 package domain
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"sync"
 	"time"
 
@@ -366,13 +369,9 @@ func (r *gatewayAdminAPIRegistry) For(gateway *models.Gateway) (janus_admin.Admi
 		return api, nil
 	}
 
-	aPwdB, err := base64.StdEncoding.DecodeString(gateway.AdminPassword)
-	if err != nil {
-		return nil, pkgerr.Wrap(err, "base64 decode admin password")
-	}
-	adminPwd, err := crypt.Decrypt(aPwdB, common.Config.Secret)
+	adminPwd, err := AdminPassword(gateway)
 	if err != nil {
-		return nil, pkgerr.Wrap(err, "decrypt admin password")
+		return nil, err
 	}
 
 	api, err := janus_admin.NewAdminAPI(gateway.AdminURL, adminPwd)
@@ -384,6 +383,21 @@ func (r *gatewayAdminAPIRegistry) For(gateway *models.Gateway) (janus_admin.Admi
 	return api, nil
 }
 
+// Remove drops the cached admin API client of the gateway so the next call to For
+// picks up changes in its admin url or password.
+func (r *gatewayAdminAPIRegistry) Remove(gateway *models.Gateway) {
+	r.lock.Lock()
+	api, ok := r.registry[gateway.ID]
+	delete(r.registry, gateway.ID)
+	r.lock.Unlock()
+
+	if ok {
+		if err := api.Close(); err != nil {
+			log.Warn().Err(err).Msgf("close admin API of gateway %s", gateway.Name)
+		}
+	}
+}
+
 func (r *gatewayAdminAPIRegistry) Get(gateway *models.Gateway) (janus_admin.AdminAPI, bool) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
@@ -396,3 +410,87 @@ func (r *gatewayAdminAPIRegistry) Set(gateway *models.Gateway, api janus_admin.A
 	r.registry[gateway.ID] = api
 	r.lock.Unlock()
 }
+
+// EncryptAdminPassword encrypts a plain text gateway admin password the way it's kept in gateways.admin_password
+func EncryptAdminPassword(pwd string) (string, error) {
+	encPwd, err := crypt.Encrypt([]byte(pwd), common.Config.Secret)
+	if err != nil {
+		return "", pkgerr.Wrap(err, "crypt.Encrypt")
+	}
+	return base64.StdEncoding.EncodeToString(encPwd), nil
+}
+
+// CheckAdminAPI verifies we can talk to a gateway's admin API with the given plain text admin password
+func CheckAdminAPI(adminURL, adminPwd string) error {
+	api, err := janus_admin.NewAdminAPI(adminURL, adminPwd)
+	if err != nil {
+		return pkgerr.Wrap(err, "janus.NewAdminAPI")
+	}
+	defer api.Close()
+
+	if _, err := api.ListSessions(); err != nil {
+		return pkgerr.Wrap(err, "Admin API list sessions")
+	}
+
+	return nil
+}
+
+// AdminPassword decrypts the admin API password of the gateway
+func AdminPassword(gateway *models.Gateway) (string, error) {
+	aPwdB, err := base64.StdEncoding.DecodeString(gateway.AdminPassword)
+	if err != nil {
+		return "", pkgerr.Wrap(err, "base64 decode admin password")
+	}
+	adminPwd, err := crypt.Decrypt(aPwdB, common.Config.Secret)
+	if err != nil {
+		return "", pkgerr.Wrap(err, "decrypt admin password")
+	}
+	return adminPwd, nil
+}
+
+var gatewayInfoClient = &http.Client{Timeout: 5 * time.Second}
+
+// GatewayInfo fetches the server info of a gateway through its admin API.
+// janus-go doesn't support the info request so we make it ourselves.
+// Janus errors are returned as *janus_admin.ErrorAMResponse.
+func GatewayInfo(gateway *models.Gateway) (map[string]interface{}, error) {
+	adminPwd, err := AdminPassword(gateway)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"janus":        "info",
+		"transaction":  stringutil.GenerateUID(12),
+		"admin_secret": adminPwd,
+	})
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "json.Marshal info request")
+	}
+
+	resp, err := gatewayInfoClient.Post(gateway.AdminURL, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "http.Post info request")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "read info response")
+	}
+
+	var info map[string]interface{}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, pkgerr.Wrapf(err, "json.Unmarshal info response [%d]", resp.StatusCode)
+	}
+
+	if info["janus"] == "error" {
+		var errResp janus_admin.ErrorAMResponse
+		if err := json.Unmarshal(body, &errResp); err != nil {
+			return nil, pkgerr.Wrap(err, "json.Unmarshal info error response")
+		}
+		return nil, &errResp
+	}
+
+	return info, nil
+}
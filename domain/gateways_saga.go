@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	janus_plugins "github.com/edoshor/janus-go/plugins"
+	"github.com/rs/zerolog/log"
+
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+const (
+	GatewayStepOK          = "ok"
+	GatewayStepFailed      = "failed"
+	GatewayStepCompensated = "compensated"
+	// Undoing failed as well, the gateway is left out of sync until the rooms syncer fixes it.
+	GatewayStepCompensationFailed = "compensation_failed"
+)
+
+// GatewaysSagaStep is a plugin request and the request undoing it.
+// Undo is nil for steps which can't be undone.
+// IfMissing, if set, is run instead when Do fails because the room it changes doesn't exist on the gateway.
+// IfExists, if set, is run when Do finds what it creates already exists on the gateway.
+// It's never undone as whatever it changes was there before the saga.
+// Check, if set, fails the step unless the response of Do passes it.
+type GatewaysSagaStep struct {
+	Do        janus_plugins.PluginRequest
+	Undo      janus_plugins.PluginRequest
+	IfMissing *GatewaysSagaStep
+	IfExists  *GatewaysSagaStep
+	Check     func(resp interface{}) error
+}
+
+type GatewayStepResult struct {
+	Gateway string `json:"gateway"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+type GatewaysSagaError struct {
+	Results []*GatewayStepResult
+}
+
+func (e *GatewaysSagaError) Error() string {
+	failed := make([]string, 0)
+	for _, result := range e.Results {
+		if result.Status != GatewayStepCompensated {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.Gateway, result.Error))
+		}
+	}
+	return fmt.Sprintf("gateways saga failed [%s]", strings.Join(failed, ", "))
+}
+
+// GatewaysSaga runs the same steps on several gateways.
+// Gateways run in parallel, the steps of each gateway run one after the other.
+// If any step fails on any gateway, the steps done so far are undone in reverse order on every gateway.
+// Steps which were already done on a gateway are not undone there.
+type GatewaysSaga struct {
+	steps    []*GatewaysSagaStep
+	gateways []*models.Gateway
+	done     [][]*GatewaysSagaStep // steps to undo, by gateway
+	Results  []*GatewayStepResult
+}
+
+func NewGatewaysSaga(gateways []*models.Gateway, steps ...*GatewaysSagaStep) *GatewaysSaga {
+	return &GatewaysSaga{
+		steps:    steps,
+		gateways: gateways,
+		done:     make([][]*GatewaysSagaStep, len(gateways)),
+		Results:  make([]*GatewayStepResult, len(gateways)),
+	}
+}
+
+// Run returns a *GatewaysSagaError if the saga failed, in which case it was already compensated
+func (s *GatewaysSaga) Run() error {
+	s.parallel(func(i int, gateway *models.Gateway) {
+		result := &GatewayStepResult{Gateway: gateway.Name, Status: GatewayStepOK}
+		s.Results[i] = result
+
+		api, err := GatewayAdminAPIRegistry.For(gateway)
+		if err != nil {
+			result.Status = GatewayStepFailed
+			result.Error = err.Error()
+			return
+		}
+
+		for _, step := range s.steps {
+			resp, err := MessagePlugin(api, step.Do)
+			if err != nil && step.IfMissing != nil && IsNoSuchRoom(err) {
+				step = step.IfMissing
+				resp, err = MessagePlugin(api, step.Do)
+			}
+			_, alreadyDone := resp.(*AlreadyDoneResponse)
+			if err == nil && alreadyDone && step.IfExists != nil {
+				step = step.IfExists
+				resp, err = MessagePlugin(api, step.Do)
+			}
+			if err == nil && step.Check != nil {
+				err = step.Check(resp)
+			}
+			if err != nil {
+				result.Status = GatewayStepFailed
+				result.Error = err.Error()
+				return
+			}
+			if !alreadyDone {
+				s.done[i] = append(s.done[i], step)
+			}
+		}
+	})
+
+	for _, result := range s.Results {
+		if result.Status == GatewayStepFailed {
+			s.Compensate()
+			return &GatewaysSagaError{Results: s.Results}
+		}
+	}
+
+	return nil
+}
+
+// Compensate undoes the steps done on every gateway.
+// Run compensates on failure by itself, callers should only compensate when failing after a successful Run.
+func (s *GatewaysSaga) Compensate() {
+	s.parallel(func(i int, gateway *models.Gateway) {
+		result := s.Results[i]
+		if result == nil || len(s.done[i]) == 0 {
+			return
+		}
+
+		err := s.undo(i, gateway)
+		if err != nil {
+			log.Error().Err(err).Str("gateway", gateway.Name).Msg("GatewaysSaga.Compensate")
+			result.Status = GatewayStepCompensationFailed
+			if result.Error != "" {
+				result.Error += "; "
+			}
+			result.Error += fmt.Sprintf("undo: %s", err.Error())
+		} else if result.Status == GatewayStepOK {
+			result.Status = GatewayStepCompensated
+		}
+	})
+}
+
+func (s *GatewaysSaga) undo(i int, gateway *models.Gateway) error {
+	api, err := GatewayAdminAPIRegistry.For(gateway)
+	if err != nil {
+		return err
+	}
+
+	for ; len(s.done[i]) > 0; s.done[i] = s.done[i][:len(s.done[i])-1] {
+		step := s.done[i][len(s.done[i])-1]
+		if step.Undo == nil {
+			return fmt.Errorf("%s %s can't be undone", step.Do.PluginName(), step.Do.ActionName())
+		}
+		if _, err := MessagePlugin(api, step.Undo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *GatewaysSaga) parallel(fn func(int, *models.Gateway)) {
+	var wg sync.WaitGroup
+	for i, gateway := range s.gateways {
+		wg.Add(1)
+		go func(i int, gateway *models.Gateway) {
+			defer wg.Done()
+			fn(i, gateway)
+		}(i, gateway)
+	}
+	wg.Wait()
+}
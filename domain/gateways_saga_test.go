@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	janus_plugins "github.com/edoshor/janus-go/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/testutil/mocks"
+)
+
+func TestGatewaysSaga(t *testing.T) {
+	factory := janus_plugins.MakeVideoroomRequestFactory("")
+	do := factory.CreateRequest(NewVideoroomRoom(1, "room", DefaultRoomProfile), true, nil)
+	undo := factory.DestroyRequest(1, true, "")
+	action := func(name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.ActionName() == name
+		})
+	}
+
+	ok := &models.Gateway{ID: -1, Name: "ok"}
+	okAPI := new(mocks.AdminAPI)
+	okAPI.On("MessagePlugin", action("create")).Return(&janus_plugins.VideoroomCreateResponse{}, nil)
+	okAPI.On("MessagePlugin", action("destroy")).Return(&janus_plugins.VideoroomDestroyResponse{}, nil)
+	GatewayAdminAPIRegistry.Set(ok, okAPI)
+
+	failing := &models.Gateway{ID: -2, Name: "failing"}
+	failingAPI := new(mocks.AdminAPI)
+	failingAPI.On("MessagePlugin", action("create")).Return(nil, errors.New("gateway is down"))
+	GatewayAdminAPIRegistry.Set(failing, failingAPI)
+
+	saga := NewGatewaysSaga([]*models.Gateway{ok})
+	assert.NoError(t, saga.Run(), "no steps")
+
+	saga = NewGatewaysSaga([]*models.Gateway{ok}, &GatewaysSagaStep{Do: do, Undo: undo})
+	assert.NoError(t, saga.Run(), "success")
+	assert.Equal(t, GatewayStepOK, saga.Results[0].Status, "status")
+	saga.Compensate()
+	assert.Equal(t, GatewayStepCompensated, saga.Results[0].Status, "compensated after success")
+	okAPI.AssertNumberOfCalls(t, "MessagePlugin", 2)
+
+	saga = NewGatewaysSaga([]*models.Gateway{ok, failing}, &GatewaysSagaStep{Do: do, Undo: undo})
+	err := saga.Run()
+	var sagaErr *GatewaysSagaError
+	assert.True(t, errors.As(err, &sagaErr), "saga error")
+	assert.Equal(t, GatewayStepCompensated, saga.Results[0].Status, "ok gateway")
+	assert.Equal(t, GatewayStepFailed, saga.Results[1].Status, "failing gateway")
+	assert.Contains(t, saga.Results[1].Error, "gateway is down", "failing gateway error")
+	okAPI.AssertNumberOfCalls(t, "MessagePlugin", 4)
+
+	saga = NewGatewaysSaga([]*models.Gateway{ok, failing}, &GatewaysSagaStep{Do: do})
+	assert.Error(t, saga.Run(), "no undo")
+	assert.Equal(t, GatewayStepCompensationFailed, saga.Results[0].Status, "can't undo")
+	assert.Equal(t, GatewayStepFailed, saga.Results[1].Status, "failing gateway")
+	okAPI.AssertNumberOfCalls(t, "MessagePlugin", 5)
+
+	// room already exists on one gateway: ok there and left as is on compensation
+	exists := &models.Gateway{ID: -3, Name: "exists"}
+	existsAPI := new(mocks.AdminAPI)
+	existsAPI.On("MessagePlugin", action("create")).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 427, Reason: "Room 1 already exists"}}, nil)
+	GatewayAdminAPIRegistry.Set(exists, existsAPI)
+
+	saga = NewGatewaysSaga([]*models.Gateway{ok, exists}, &GatewaysSagaStep{Do: do, Undo: undo})
+	assert.NoError(t, saga.Run(), "already exists")
+	assert.Equal(t, GatewayStepOK, saga.Results[1].Status, "already exists status")
+
+	saga = NewGatewaysSaga([]*models.Gateway{exists, failing}, &GatewaysSagaStep{Do: do, Undo: undo})
+	assert.Error(t, saga.Run(), "already exists and failing")
+	assert.Equal(t, GatewayStepOK, saga.Results[0].Status, "nothing to compensate")
+	existsAPI.AssertNotCalled(t, "MessagePlugin", action("destroy"))
+}
+
+func TestGatewaysSagaIfMissing(t *testing.T) {
+	steps := EditRoomSteps(1, "old", DefaultRoomProfile, "new", DefaultRoomProfile)
+	request := func(plugin, name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.PluginName() == plugin && r.ActionName() == name
+		})
+	}
+
+	// room created before textrooms were managed
+	gateway := &models.Gateway{ID: -4, Name: "no_textroom"}
+	api := new(mocks.AdminAPI)
+	api.On("MessagePlugin", request("janus.plugin.videoroom", "edit")).Return(&janus_plugins.VideoroomEditResponse{}, nil)
+	api.On("MessagePlugin", request("janus.plugin.textroom", "edit")).
+		Return(&janus_plugins.TextroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 417, Reason: "No such room"}}, nil)
+	api.On("MessagePlugin", request("janus.plugin.textroom", "create")).Return(&janus_plugins.TextroomCreateResponse{}, nil).Once()
+	api.On("MessagePlugin", request("janus.plugin.textroom", "destroy")).Return(&janus_plugins.TextroomDestroyResponse{}, nil).Once()
+	GatewayAdminAPIRegistry.Set(gateway, api)
+
+	saga := NewGatewaysSaga([]*models.Gateway{gateway}, steps...)
+	assert.NoError(t, saga.Run(), "textroom created")
+	saga.Compensate()
+	assert.Equal(t, GatewayStepCompensated, saga.Results[0].Status, "compensated")
+	api.AssertExpectations(t)
+
+	// and destroying it is fine too
+	api.On("MessagePlugin", request("janus.plugin.videoroom", "destroy")).Return(&janus_plugins.VideoroomDestroyResponse{}, nil)
+	api.On("MessagePlugin", request("janus.plugin.textroom", "destroy")).
+		Return(&janus_plugins.TextroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 417, Reason: "No such room"}}, nil)
+	saga = NewGatewaysSaga([]*models.Gateway{gateway}, DestroyRoomSteps(1, "new", DefaultRoomProfile)...)
+	assert.NoError(t, saga.Run(), "textroom already gone")
+}
+
+func TestGatewaysSagaVideoroomMissing(t *testing.T) {
+	steps := EditRoomSteps(1, "old", DefaultRoomProfile, "new", DefaultRoomProfile)
+	request := func(plugin, name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.PluginName() == plugin && r.ActionName() == name
+		})
+	}
+
+	// gateway restarted with a config lacking the videoroom
+	gateway := &models.Gateway{ID: -5, Name: "no_videoroom"}
+	api := new(mocks.AdminAPI)
+	api.On("MessagePlugin", request("janus.plugin.videoroom", "edit")).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 426, Reason: "No such room"}}, nil)
+	api.On("MessagePlugin", mock.MatchedBy(func(r *janus_plugins.VideoroomCreateRequest) bool {
+		return r.Room.Room == 1 && r.Room.Description == "new"
+	})).Return(&janus_plugins.VideoroomCreateResponse{}, nil).Once()
+	api.On("MessagePlugin", request("janus.plugin.textroom", "edit")).Return(&janus_plugins.TextroomEditResponse{}, nil)
+	GatewayAdminAPIRegistry.Set(gateway, api)
+
+	saga := NewGatewaysSaga([]*models.Gateway{gateway}, steps...)
+	assert.NoError(t, saga.Run(), "videoroom created")
+	assert.Equal(t, GatewayStepOK, saga.Results[0].Status, "status")
+	api.AssertExpectations(t)
+}
+
+func TestGatewaysSagaIfExists(t *testing.T) {
+	steps := CreateRoomSteps(1, "room", DefaultRoomProfile)
+	request := func(plugin, name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.PluginName() == plugin && r.ActionName() == name
+		})
+	}
+
+	// stale room left by an earlier failed run
+	gateway := &models.Gateway{ID: -6, Name: "stale"}
+	api := new(mocks.AdminAPI)
+	api.On("MessagePlugin", request("janus.plugin.videoroom", "create")).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 427, Reason: "Room 1 already exists"}}, nil).Once()
+	api.On("MessagePlugin", mock.MatchedBy(func(r *janus_plugins.VideoroomEditRequest) bool {
+		return r.Room.Room == 1 && r.Room.Description == "room" && r.Room.Publishers == DefaultRoomProfile.Publishers
+	})).Return(&janus_plugins.VideoroomEditResponse{}, nil).Once()
+	api.On("MessagePlugin", request("janus.plugin.textroom", "create")).
+		Return(&janus_plugins.TextroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 418, Reason: "Room 1 already exists"}}, nil).Once()
+	api.On("MessagePlugin", request("janus.plugin.textroom", "edit")).Return(&janus_plugins.TextroomEditResponse{}, nil).Once()
+	GatewayAdminAPIRegistry.Set(gateway, api)
+
+	saga := NewGatewaysSaga([]*models.Gateway{gateway}, steps...)
+	assert.NoError(t, saga.Run(), "edited to requested settings")
+	saga.Compensate()
+	assert.Equal(t, GatewayStepOK, saga.Results[0].Status, "existing room is not undone")
+	api.AssertExpectations(t)
+	api.AssertNotCalled(t, "MessagePlugin", request("janus.plugin.videoroom", "destroy"))
+}
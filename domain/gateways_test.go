@@ -2,8 +2,10 @@ package domain
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
+	janus_admin "github.com/edoshor/janus-go/admin"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/Bnei-Baruch/gxydb-api/common"
@@ -68,6 +70,17 @@ func (s *GatewaysTestSuite) TestRotateTokensWrongAdminPwd() {
 	s.Error(err, "err")
 }
 
+func (s *GatewaysTestSuite) TestGatewayInfo() {
+	info, err := GatewayInfo(s.createGateway())
+	s.Require().NoError(err, "GatewayInfo")
+	s.Equal("server_info", info["janus"], "janus")
+
+	_, err = GatewayInfo(s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, "wrong_password"))
+	var tErr *janus_admin.ErrorAMResponse
+	s.Require().True(errors.As(err, &tErr), "janus error")
+	s.Equal(403, tErr.Err.Code, "unauthorized code")
+}
+
 func (s *GatewaysTestSuite) createGateway() *models.Gateway {
 	return s.CreateGatewayP(common.GatewayTypeRooms, s.GatewayManager.Config.AdminURL, s.GatewayManager.Config.AdminSecret)
 }
@@ -0,0 +1,640 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edoshor/janus-go"
+	janus_admin "github.com/edoshor/janus-go/admin"
+	janus_plugins "github.com/edoshor/janus-go/plugins"
+	pkgerr "github.com/pkg/errors"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+// Plugin error codes of requests which are already done on the gateway
+const (
+	videoroomErrorNoSuchRoom       = 426
+	videoroomErrorRoomExists       = 427
+	videoroomErrorNoSuchFeed       = 428
+	textroomErrorNoSuchRoom        = 417
+	textroomErrorRoomExists        = 418
+	streamingErrorNoSuchMountpoint = 455
+	streamingErrorCantCreate       = 456
+)
+
+// AlreadyDoneResponse is returned by MessagePlugin for plugin errors saying there's nothing to do,
+// like destroying a room which doesn't exist, creating one which does or kicking a participant who left.
+// Saga steps creating rooms or mountpoints check what already exists with GatewaysSagaStep.IfExists.
+type AlreadyDoneResponse struct {
+	janus_plugins.PluginError
+}
+
+// MessagePlugin sends a plugin request through the admin API.
+// Plugin error responses are returned as errors, except for those of requests already done.
+func MessagePlugin(api janus_admin.AdminAPI, request janus_plugins.PluginRequest) (interface{}, error) {
+	resp, err := api.MessagePlugin(request)
+	if err != nil {
+		return nil, pkgerr.Wrapf(err, "api.MessagePlugin [%s %s]", request.PluginName(), request.ActionName())
+	}
+
+	var pErr *janus_plugins.PluginError
+	switch errResp := resp.(type) {
+	case *janus_plugins.VideoroomErrorResponse:
+		pErr = &errResp.PluginError
+	case *janus_plugins.TextroomErrorResponse:
+		pErr = &errResp.PluginError
+	case *StreamingErrorResponse:
+		pErr = &errResp.PluginError
+	default:
+		return resp, nil
+	}
+
+	if alreadyDone(request, pErr) {
+		return &AlreadyDoneResponse{PluginError: *pErr}, nil
+	}
+	return nil, resp.(error)
+}
+
+func alreadyDone(request janus_plugins.PluginRequest, pErr *janus_plugins.PluginError) bool {
+	switch request.ActionName() {
+	case "create":
+		return (pErr.Code == videoroomErrorRoomExists && request.PluginName() == "janus.plugin.videoroom") ||
+			(pErr.Code == textroomErrorRoomExists && request.PluginName() == "janus.plugin.textroom") ||
+			// the streaming plugin has no dedicated code for it
+			(pErr.Code == streamingErrorCantCreate && request.PluginName() == StreamingPlugin &&
+				strings.Contains(pErr.Reason, "already exists"))
+	case "destroy":
+		return isNoSuchRoom(request.PluginName(), pErr)
+	case "kick":
+		// participant, or the whole room, is gone already
+		return request.PluginName() == "janus.plugin.videoroom" &&
+			(pErr.Code == videoroomErrorNoSuchFeed || pErr.Code == videoroomErrorNoSuchRoom)
+	}
+	return false
+}
+
+func isNoSuchRoom(plugin string, pErr *janus_plugins.PluginError) bool {
+	switch plugin {
+	case "janus.plugin.videoroom":
+		return pErr.Code == videoroomErrorNoSuchRoom
+	case "janus.plugin.textroom":
+		return pErr.Code == textroomErrorNoSuchRoom
+	case StreamingPlugin:
+		return pErr.Code == streamingErrorNoSuchMountpoint
+	}
+	return false
+}
+
+// IsNoSuchRoom tells if err is a plugin error response saying the room or mountpoint doesn't exist
+func IsNoSuchRoom(err error) bool {
+	var vErr *janus_plugins.VideoroomErrorResponse
+	var tErr *janus_plugins.TextroomErrorResponse
+	var sErr *StreamingErrorResponse
+	switch {
+	case errors.As(err, &vErr):
+		return isNoSuchRoom("janus.plugin.videoroom", &vErr.PluginError)
+	case errors.As(err, &tErr):
+		return isNoSuchRoom("janus.plugin.textroom", &tErr.PluginError)
+	case errors.As(err, &sErr):
+		return isNoSuchRoom(StreamingPlugin, &sErr.PluginError)
+	}
+	return false
+}
+
+// NewVideoroomRoom returns the videoroom we create on rooms gateways for a room with the given profile
+func NewVideoroomRoom(gatewayUID int, name string, profile *models.RoomProfile) *janus_plugins.VideoroomRoom {
+	return &janus_plugins.VideoroomRoom{
+		Room:               gatewayUID,
+		Description:        name,
+		Secret:             common.Config.GatewayRoomsSecret,
+		Publishers:         profile.Publishers,
+		Bitrate:            profile.Bitrate,
+		FirFreq:            profile.FirFreq,
+		AudioCodec:         profile.Audiocodec,
+		VideoCodec:         profile.Videocodec,
+		H264Profile:        profile.H264Profile.String,
+		OpusFec:            profile.OpusFec,
+		AudioLevelExt:      profile.AudiolevelExt,
+		AudioLevelEvent:    profile.AudiolevelEvent,
+		AudioActivePackets: profile.AudioActivePackets.Int,
+		AudioLevelAverage:  profile.AudioLevelAverage.Int,
+		VideoOrientExt:     profile.VideoorientExt,
+		PlayoutDelayExt:    profile.PlayoutdelayExt,
+		TransportWideCCExt: profile.TransportWideCCExt,
+	}
+}
+
+// NewTextroomRoom returns the textroom we create on rooms gateways for a room
+func NewTextroomRoom(gatewayUID int, name string) *janus_plugins.TextroomRoom {
+	return &janus_plugins.TextroomRoom{
+		Room:        gatewayUID,
+		Description: name,
+		Secret:      common.Config.GatewayRoomsSecret,
+		Post:        common.Config.TextroomPostURL,
+	}
+}
+
+// NewTextroomRoomForEdit returns the editable settings of NewTextroomRoom
+func NewTextroomRoomForEdit(gatewayUID int, name string) *janus_plugins.TextroomRoomForEdit {
+	return &janus_plugins.TextroomRoomForEdit{
+		Room:        gatewayUID,
+		Description: name,
+		Post:        common.Config.TextroomPostURL,
+	}
+}
+
+// NewVideoroomRoomForEdit returns the editable settings of NewVideoroomRoom.
+// Codecs and RTP extensions can't be edited, existing videorooms keep them until recreated.
+func NewVideoroomRoomForEdit(gatewayUID int, name string, profile *models.RoomProfile) *janus_plugins.VideoroomRoomForEdit {
+	return &janus_plugins.VideoroomRoomForEdit{
+		Room:        gatewayUID,
+		Description: name,
+		Publishers:  profile.Publishers,
+		Bitrate:     profile.Bitrate,
+		FirFreq:     profile.FirFreq,
+	}
+}
+
+// A room on rooms gateways is a videoroom and a textroom with the same id.
+// These are the saga steps managing both.
+// Rooms created before we managed textrooms might lack them: destroying those is a no-op and editing creates them.
+// Gateways might also lose a videoroom (e.g. restarted with an old config), editing creates it as well.
+// Creating a room which is already on a gateway, e.g. left there by an earlier failed run, edits it to our settings.
+
+// CreateRoomSteps creates a room on a gateway
+func CreateRoomSteps(gatewayUID int, name string, profile *models.RoomProfile) []*GatewaysSagaStep {
+	vrFactory := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	trFactory := janus_plugins.MakeTextroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	return []*GatewaysSagaStep{
+		{
+			Do:   vrFactory.CreateRequest(NewVideoroomRoom(gatewayUID, name, profile), true, nil),
+			Undo: vrFactory.DestroyRequest(gatewayUID, true, common.Config.GatewayRoomsSecret),
+			IfExists: &GatewaysSagaStep{
+				Do: vrFactory.EditRequest(NewVideoroomRoomForEdit(gatewayUID, name, profile), true, common.Config.GatewayRoomsSecret),
+			},
+		},
+		{
+			Do:   trFactory.CreateRequest(NewTextroomRoom(gatewayUID, name), true, nil),
+			Undo: trFactory.DestroyRequest(gatewayUID, true, common.Config.GatewayRoomsSecret),
+			IfExists: &GatewaysSagaStep{
+				Do: trFactory.EditRequest(NewTextroomRoomForEdit(gatewayUID, name), true, common.Config.GatewayRoomsSecret),
+			},
+		},
+	}
+}
+
+// EditRoomSteps renames a room on a gateway and applies its profile
+func EditRoomSteps(gatewayUID int, oldName string, oldProfile *models.RoomProfile, newName string, newProfile *models.RoomProfile) []*GatewaysSagaStep {
+	vrFactory := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	trFactory := janus_plugins.MakeTextroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	return []*GatewaysSagaStep{
+		{
+			Do:   vrFactory.EditRequest(NewVideoroomRoomForEdit(gatewayUID, newName, newProfile), true, common.Config.GatewayRoomsSecret),
+			Undo: vrFactory.EditRequest(NewVideoroomRoomForEdit(gatewayUID, oldName, oldProfile), true, common.Config.GatewayRoomsSecret),
+			IfMissing: &GatewaysSagaStep{
+				Do:   vrFactory.CreateRequest(NewVideoroomRoom(gatewayUID, newName, newProfile), true, nil),
+				Undo: vrFactory.DestroyRequest(gatewayUID, true, common.Config.GatewayRoomsSecret),
+			},
+		},
+		{
+			Do:   trFactory.EditRequest(NewTextroomRoomForEdit(gatewayUID, newName), true, common.Config.GatewayRoomsSecret),
+			Undo: trFactory.EditRequest(NewTextroomRoomForEdit(gatewayUID, oldName), true, common.Config.GatewayRoomsSecret),
+			IfMissing: &GatewaysSagaStep{
+				Do:   trFactory.CreateRequest(NewTextroomRoom(gatewayUID, newName), true, nil),
+				Undo: trFactory.DestroyRequest(gatewayUID, true, common.Config.GatewayRoomsSecret),
+			},
+		},
+	}
+}
+
+// DestroyRoomSteps destroys a room on a gateway
+func DestroyRoomSteps(gatewayUID int, name string, profile *models.RoomProfile) []*GatewaysSagaStep {
+	vrFactory := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	trFactory := janus_plugins.MakeTextroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	return []*GatewaysSagaStep{
+		{
+			Do:   vrFactory.DestroyRequest(gatewayUID, true, common.Config.GatewayRoomsSecret),
+			Undo: vrFactory.CreateRequest(NewVideoroomRoom(gatewayUID, name, profile), true, nil),
+		},
+		{
+			Do:   trFactory.DestroyRequest(gatewayUID, true, common.Config.GatewayRoomsSecret),
+			Undo: trFactory.CreateRequest(NewTextroomRoom(gatewayUID, name), true, nil),
+		},
+	}
+}
+
+// mountpointDescription is the description of a mountpoint on streaming gateways, its name if it has none
+func mountpointDescription(mountpoint *models.Mountpoint) string {
+	if mountpoint.Description.String != "" {
+		return mountpoint.Description.String
+	}
+	return mountpoint.Name
+}
+
+// NewStreamingMountpoint returns the mountpoint we create on streaming gateways
+func NewStreamingMountpoint(mountpoint *models.Mountpoint) *StreamingMountpoint {
+	return &StreamingMountpoint{
+		ID:          mountpoint.GatewayUID,
+		Name:        mountpoint.Name,
+		Description: mountpointDescription(mountpoint),
+		Secret:      common.Config.MountpointsSecret,
+		Audio:       mountpoint.Audio,
+		AudioPort:   mountpoint.AudioPort.Int,
+		AudioPT:     mountpoint.AudioPT.Int,
+		AudioRtpmap: mountpoint.AudioRtpmap.String,
+		Video:       mountpoint.Video,
+		VideoPort:   mountpoint.VideoPort.Int,
+		VideoPT:     mountpoint.VideoPT.Int,
+		VideoRtpmap: mountpoint.VideoRtpmap.String,
+		VideoFMTP:   mountpoint.VideoFMTP.String,
+		Data:        mountpoint.Data,
+		DataPort:    mountpoint.DataPort.Int,
+	}
+}
+
+// CreateMountpointSteps creates a mountpoint on a gateway.
+// A mountpoint which already exists is kept only if its media settings are the ones we create.
+func CreateMountpointSteps(mountpoint *models.Mountpoint) []*GatewaysSagaStep {
+	factory := MakeStreamingRequestFactory(common.Config.GatewayPluginAdminKey)
+	requested := NewStreamingMountpoint(mountpoint)
+	return []*GatewaysSagaStep{
+		{
+			Do:   factory.CreateRequest(requested, true),
+			Undo: factory.DestroyRequest(mountpoint.GatewayUID, true, common.Config.MountpointsSecret),
+			IfExists: &GatewaysSagaStep{
+				Do: factory.InfoRequest(mountpoint.GatewayUID, common.Config.MountpointsSecret),
+				Check: func(resp interface{}) error {
+					info, ok := resp.(*StreamingInfoResponse)
+					if !ok || info.Info == nil {
+						return pkgerr.Errorf("mountpoint %d exists, unexpected info response %T", mountpoint.GatewayUID, resp)
+					}
+					if drift := mountpointDrift(info.Info.AsMountpoint(), requested); drift != "" {
+						return pkgerr.Errorf("mountpoint %d exists with other settings: %s", mountpoint.GatewayUID, drift)
+					}
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// MountpointRecreated tells if EditMountpointSteps recreates the mountpoint, cutting its live viewers.
+// Only the description of a mountpoint can be edited, other changes recreate it.
+func MountpointRecreated(old, updated *models.Mountpoint) bool {
+	if old.Disabled || updated.Disabled {
+		return false
+	}
+
+	return mountpointDrift(NewStreamingMountpoint(old), NewStreamingMountpoint(updated)) != ""
+}
+
+// mountpointDrift describes how the media settings of mountpoint differ from those of expected, if at all
+func mountpointDrift(mountpoint, expected *StreamingMountpoint) string {
+	switch {
+	case mountpoint.Audio != expected.Audio:
+		return fmt.Sprintf("audio %t != %t", mountpoint.Audio, expected.Audio)
+	case mountpoint.AudioPort != expected.AudioPort:
+		return fmt.Sprintf("audioport %d != %d", mountpoint.AudioPort, expected.AudioPort)
+	case mountpoint.AudioPT != expected.AudioPT:
+		return fmt.Sprintf("audiopt %d != %d", mountpoint.AudioPT, expected.AudioPT)
+	case mountpoint.AudioRtpmap != expected.AudioRtpmap:
+		return fmt.Sprintf("audiortpmap %q != %q", mountpoint.AudioRtpmap, expected.AudioRtpmap)
+	case mountpoint.Video != expected.Video:
+		return fmt.Sprintf("video %t != %t", mountpoint.Video, expected.Video)
+	case mountpoint.VideoPort != expected.VideoPort:
+		return fmt.Sprintf("videoport %d != %d", mountpoint.VideoPort, expected.VideoPort)
+	case mountpoint.VideoPT != expected.VideoPT:
+		return fmt.Sprintf("videopt %d != %d", mountpoint.VideoPT, expected.VideoPT)
+	case mountpoint.VideoRtpmap != expected.VideoRtpmap:
+		return fmt.Sprintf("videortpmap %q != %q", mountpoint.VideoRtpmap, expected.VideoRtpmap)
+	case mountpoint.VideoFMTP != expected.VideoFMTP:
+		return fmt.Sprintf("videofmtp %q != %q", mountpoint.VideoFMTP, expected.VideoFMTP)
+	case mountpoint.Data != expected.Data:
+		return fmt.Sprintf("data %t != %t", mountpoint.Data, expected.Data)
+	case mountpoint.DataPort != expected.DataPort:
+		return fmt.Sprintf("dataport %d != %d", mountpoint.DataPort, expected.DataPort)
+	}
+	return ""
+}
+
+// EditMountpointSteps brings a mountpoint on a gateway from old to updated.
+// Disabled mountpoints are kept off gateways: disabling destroys a mountpoint and enabling creates it.
+func EditMountpointSteps(old, updated *models.Mountpoint) []*GatewaysSagaStep {
+	factory := MakeStreamingRequestFactory(common.Config.GatewayPluginAdminKey)
+
+	switch {
+	case old.Disabled && updated.Disabled:
+		return nil
+	case old.Disabled:
+		return CreateMountpointSteps(updated)
+	case updated.Disabled:
+		return DestroyMountpointSteps(old)
+	}
+
+	if !MountpointRecreated(old, updated) {
+		oldDescription, updatedDescription := mountpointDescription(old), mountpointDescription(updated)
+		if oldDescription == updatedDescription {
+			return nil
+		}
+		return []*GatewaysSagaStep{
+			{
+				Do:   factory.EditRequest(updated.GatewayUID, updatedDescription, true, common.Config.MountpointsSecret),
+				Undo: factory.EditRequest(old.GatewayUID, oldDescription, true, common.Config.MountpointsSecret),
+			},
+		}
+	}
+
+	return append(DestroyMountpointSteps(old), CreateMountpointSteps(updated)...)
+}
+
+// DestroyMountpointSteps destroys a mountpoint on a gateway
+func DestroyMountpointSteps(mountpoint *models.Mountpoint) []*GatewaysSagaStep {
+	factory := MakeStreamingRequestFactory(common.Config.GatewayPluginAdminKey)
+	return []*GatewaysSagaStep{
+		{
+			Do:   factory.DestroyRequest(mountpoint.GatewayUID, true, common.Config.MountpointsSecret),
+			Undo: factory.CreateRequest(NewStreamingMountpoint(mountpoint), true),
+		},
+	}
+}
+
+// Plugin requests we need which are missing from janus-go
+
+type VideoroomKickRequest struct {
+	janus_plugins.BasePluginRequest
+	RoomID int
+	ID     int64
+	Secret string
+}
+
+func NewVideoroomKickRequest(adminKey string, roomID int, id int64, secret string) *VideoroomKickRequest {
+	return &VideoroomKickRequest{
+		BasePluginRequest: janus_plugins.BasePluginRequest{
+			Plugin:   "janus.plugin.videoroom",
+			Action:   "kick",
+			AdminKey: adminKey,
+		},
+		RoomID: roomID,
+		ID:     id,
+		Secret: secret,
+	}
+}
+
+func (r *VideoroomKickRequest) Payload() map[string]interface{} {
+	payload := r.BasePluginRequest.Payload()
+	payload["room"] = r.RoomID
+	payload["id"] = r.ID
+	if r.Secret != "" {
+		payload["secret"] = r.Secret
+	}
+	return payload
+}
+
+// Streaming plugin, missing from janus-go altogether.
+// Only RTP mountpoints are supported.
+
+const StreamingPlugin = "janus.plugin.streaming"
+
+func init() {
+	janus_plugins.TypeMap[StreamingPlugin] = map[string]func() interface{}{
+		"error":   func() interface{} { return &StreamingErrorResponse{} },
+		"list":    func() interface{} { return &StreamingListResponse{} },
+		"info":    func() interface{} { return &StreamingInfoResponse{} },
+		"create":  func() interface{} { return &StreamingCreateResponse{} },
+		"edit":    func() interface{} { return &StreamingEditResponse{} },
+		"destroy": func() interface{} { return &StreamingDestroyResponse{} },
+	}
+}
+
+type StreamingResponse struct {
+	Streaming string `json:"streaming"`
+}
+
+type StreamingErrorResponse struct {
+	StreamingResponse
+	janus_plugins.PluginError
+}
+
+func (err *StreamingErrorResponse) Error() string {
+	return err.PluginError.Error()
+}
+
+type StreamingRequestFactory struct {
+	janus_plugins.PluginRequestFactory
+}
+
+func MakeStreamingRequestFactory(adminKey string) *StreamingRequestFactory {
+	return &StreamingRequestFactory{
+		PluginRequestFactory: *janus_plugins.NewPluginRequestFactory(StreamingPlugin, adminKey),
+	}
+}
+
+func (f *StreamingRequestFactory) make(action string) janus_plugins.BasePluginRequest {
+	return janus_plugins.BasePluginRequest{
+		Plugin:   f.Plugin,
+		Action:   action,
+		AdminKey: f.AdminKey,
+	}
+}
+
+func (f *StreamingRequestFactory) ListRequest() *janus_plugins.BasePluginRequest {
+	request := f.make("list")
+	return &request
+}
+
+func (f *StreamingRequestFactory) InfoRequest(id int, secret string) *StreamingInfoRequest {
+	return &StreamingInfoRequest{
+		BasePluginRequest: f.make("info"),
+		ID:                id,
+		Secret:            secret,
+	}
+}
+
+func (f *StreamingRequestFactory) CreateRequest(mountpoint *StreamingMountpoint, permanent bool) *StreamingCreateRequest {
+	return &StreamingCreateRequest{
+		BasePluginRequest: f.make("create"),
+		Mountpoint:        mountpoint,
+		Permanent:         permanent,
+	}
+}
+
+func (f *StreamingRequestFactory) EditRequest(id int, description string, permanent bool, secret string) *StreamingEditRequest {
+	return &StreamingEditRequest{
+		BasePluginRequest: f.make("edit"),
+		ID:                id,
+		Description:       description,
+		Permanent:         permanent,
+		Secret:            secret,
+	}
+}
+
+func (f *StreamingRequestFactory) DestroyRequest(id int, permanent bool, secret string) *StreamingDestroyRequest {
+	return &StreamingDestroyRequest{
+		BasePluginRequest: f.make("destroy"),
+		ID:                id,
+		Permanent:         permanent,
+		Secret:            secret,
+	}
+}
+
+type StreamingMountpoint struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Secret      string `json:"secret,omitempty"`
+	Audio       bool   `json:"audio"`
+	AudioPort   int    `json:"audioport,omitempty"`
+	AudioPT     int    `json:"audiopt"` // 0 is PCMU
+	AudioRtpmap string `json:"audiortpmap,omitempty"`
+	Video       bool   `json:"video"`
+	VideoPort   int    `json:"videoport,omitempty"`
+	VideoPT     int    `json:"videopt"`
+	VideoRtpmap string `json:"videortpmap,omitempty"`
+	VideoFMTP   string `json:"videofmtp,omitempty"`
+	Data        bool   `json:"data"`
+	DataPort    int    `json:"dataport,omitempty"`
+}
+
+func (m *StreamingMountpoint) AsMap() map[string]interface{} {
+	asMap, _ := janus.StructToMap(m)
+	return asMap
+}
+
+type StreamingMountpointFromList struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type StreamingListResponse struct {
+	StreamingResponse
+	Mountpoints []*StreamingMountpointFromList `json:"list"`
+}
+
+type StreamingInfoRequest struct {
+	janus_plugins.BasePluginRequest
+	ID     int
+	Secret string
+}
+
+func (r *StreamingInfoRequest) Payload() map[string]interface{} {
+	payload := r.BasePluginRequest.Payload()
+	payload["id"] = r.ID
+	if r.Secret != "" {
+		payload["secret"] = r.Secret // media details are only reported with the secret
+	}
+	return payload
+}
+
+// StreamingMountpointInfo is the info of an RTP mountpoint.
+// Media are reported by the port the gateway listens on for them.
+type StreamingMountpointInfo struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	AudioPort   int    `json:"audio_port"`
+	AudioPT     int    `json:"audio_pt"`
+	AudioRtpmap string `json:"audio_rtpmap"`
+	VideoPort   int    `json:"video_port"`
+	VideoPT     int    `json:"video_pt"`
+	VideoRtpmap string `json:"video_rtpmap"`
+	VideoFMTP   string `json:"video_fmtp"`
+	DataPort    int    `json:"data_port"`
+}
+
+// AsMountpoint returns the info as the mountpoint we create, without name and secret
+func (i *StreamingMountpointInfo) AsMountpoint() *StreamingMountpoint {
+	return &StreamingMountpoint{
+		ID:          i.ID,
+		Description: i.Description,
+		Audio:       i.AudioPort != 0,
+		AudioPort:   i.AudioPort,
+		AudioPT:     i.AudioPT,
+		AudioRtpmap: i.AudioRtpmap,
+		Video:       i.VideoPort != 0,
+		VideoPort:   i.VideoPort,
+		VideoPT:     i.VideoPT,
+		VideoRtpmap: i.VideoRtpmap,
+		VideoFMTP:   i.VideoFMTP,
+		Data:        i.DataPort != 0,
+		DataPort:    i.DataPort,
+	}
+}
+
+type StreamingInfoResponse struct {
+	StreamingResponse
+	Info *StreamingMountpointInfo `json:"info"`
+}
+
+type StreamingCreateRequest struct {
+	janus_plugins.BasePluginRequest
+	Mountpoint *StreamingMountpoint
+	Permanent  bool
+}
+
+func (r *StreamingCreateRequest) Payload() map[string]interface{} {
+	payload := r.BasePluginRequest.Payload()
+	for k, v := range r.Mountpoint.AsMap() {
+		payload[k] = v
+	}
+	payload["type"] = "rtp"
+	payload["permanent"] = r.Permanent
+	return payload
+}
+
+type StreamingCreateResponse struct {
+	StreamingResponse
+	Created   string `json:"created"`
+	Permanent bool   `json:"permanent"`
+}
+
+type StreamingEditRequest struct {
+	janus_plugins.BasePluginRequest
+	ID          int
+	Description string
+	Permanent   bool
+	Secret      string
+}
+
+func (r *StreamingEditRequest) Payload() map[string]interface{} {
+	payload := r.BasePluginRequest.Payload()
+	payload["id"] = r.ID
+	payload["new_description"] = r.Description
+	payload["permanent"] = r.Permanent
+	if r.Secret != "" {
+		payload["secret"] = r.Secret
+	}
+	return payload
+}
+
+type StreamingEditResponse struct {
+	StreamingResponse
+	ID        int  `json:"id"`
+	Permanent bool `json:"permanent"`
+}
+
+type StreamingDestroyRequest struct {
+	janus_plugins.BasePluginRequest
+	ID        int
+	Permanent bool
+	Secret    string
+}
+
+func (r *StreamingDestroyRequest) Payload() map[string]interface{} {
+	payload := r.BasePluginRequest.Payload()
+	payload["id"] = r.ID
+	payload["permanent"] = r.Permanent
+	if r.Secret != "" {
+		payload["secret"] = r.Secret
+	}
+	return payload
+}
+
+type StreamingDestroyResponse struct {
+	StreamingResponse
+	ID int `json:"id"`
+}
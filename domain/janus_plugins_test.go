@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	janus_plugins "github.com/edoshor/janus-go/plugins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/volatiletech/null"
+
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/testutil/mocks"
+)
+
+func TestStreamingCreateRequest(t *testing.T) {
+	mountpoint := &models.Mountpoint{
+		Name:        "main",
+		GatewayUID:  10,
+		Video:       true,
+		VideoPort:   null.IntFrom(5004),
+		VideoPT:     null.IntFrom(100),
+		VideoRtpmap: null.StringFrom("VP8/90000"),
+	}
+
+	payload := MakeStreamingRequestFactory("admin_key").CreateRequest(NewStreamingMountpoint(mountpoint), true).Payload()
+	assert.Equal(t, "create", payload["request"], "request")
+	assert.Equal(t, "admin_key", payload["admin_key"], "admin_key")
+	assert.Equal(t, "rtp", payload["type"], "type")
+	assert.Equal(t, true, payload["permanent"], "permanent")
+	assert.EqualValues(t, 10, payload["id"], "id")
+	assert.Equal(t, true, payload["video"], "video")
+	assert.EqualValues(t, 5004, payload["videoport"], "videoport")
+	assert.Equal(t, false, payload["audio"], "audio")
+	assert.NotContains(t, payload, "audioport", "audioport")
+
+	mountpoint.Audio = true
+	mountpoint.AudioPort = null.IntFrom(5002)
+	mountpoint.AudioPT = null.IntFrom(0)
+	mountpoint.AudioRtpmap = null.StringFrom("PCMU/8000")
+	payload = MakeStreamingRequestFactory("admin_key").CreateRequest(NewStreamingMountpoint(mountpoint), true).Payload()
+	assert.Contains(t, payload, "audiopt", "PCMU audiopt")
+	assert.EqualValues(t, 0, payload["audiopt"], "PCMU audiopt")
+}
+
+func TestEditMountpointSteps(t *testing.T) {
+	old := &models.Mountpoint{
+		Name:       "main",
+		GatewayUID: 10,
+		Data:       true,
+		DataPort:   null.IntFrom(5006),
+	}
+
+	updated := *old
+	assert.Empty(t, EditMountpointSteps(old, &updated), "no changes")
+
+	updated.Name = "renamed"
+	steps := EditMountpointSteps(old, &updated)
+	assert.Len(t, steps, 1, "rename")
+	assert.Equal(t, "edit", steps[0].Do.ActionName(), "rename do")
+	assert.Equal(t, "renamed", steps[0].Do.(*StreamingEditRequest).Description, "rename do description")
+	assert.Equal(t, "edit", steps[0].Undo.ActionName(), "rename undo")
+	assert.Equal(t, "main", steps[0].Undo.(*StreamingEditRequest).Description, "rename undo description")
+	assert.False(t, MountpointRecreated(old, &updated), "rename recreated")
+
+	updated.Description = null.StringFrom("description")
+	steps = EditMountpointSteps(old, &updated)
+	assert.Len(t, steps, 1, "description")
+	assert.Equal(t, "description", steps[0].Do.(*StreamingEditRequest).Description, "description do")
+
+	described := updated
+	described.Name = "renamed again"
+	assert.Empty(t, EditMountpointSteps(&updated, &described), "rename with description")
+
+	updated.DataPort = null.IntFrom(5008)
+	steps = EditMountpointSteps(old, &updated)
+	assert.Len(t, steps, 2, "recreate")
+	assert.Equal(t, "destroy", steps[0].Do.ActionName(), "recreate first step")
+	assert.Equal(t, "create", steps[1].Do.ActionName(), "recreate second step")
+	assert.True(t, MountpointRecreated(old, &updated), "recreated")
+
+	updated.Disabled = true
+	steps = EditMountpointSteps(old, &updated)
+	assert.Len(t, steps, 1, "disable")
+	assert.Equal(t, "destroy", steps[0].Do.ActionName(), "disable do")
+	assert.False(t, MountpointRecreated(old, &updated), "disable recreated")
+
+	disabled := *old
+	disabled.Disabled = true
+	assert.Empty(t, EditMountpointSteps(&disabled, &updated), "disabled")
+
+	steps = EditMountpointSteps(&updated, old)
+	assert.Len(t, steps, 1, "enable")
+	assert.Equal(t, "create", steps[0].Do.ActionName(), "enable do")
+}
+
+func TestMessagePluginMountpointExists(t *testing.T) {
+	request := MakeStreamingRequestFactory("").CreateRequest(NewStreamingMountpoint(&models.Mountpoint{GatewayUID: 10}), true)
+
+	api := new(mocks.AdminAPI)
+	api.On("MessagePlugin", request).
+		Return(&StreamingErrorResponse{PluginError: janus_plugins.PluginError{Code: 456, Reason: "A stream with the provided ID already exists"}}, nil).Once()
+	resp, err := MessagePlugin(api, request)
+	assert.NoError(t, err, "already exists")
+	assert.IsType(t, &AlreadyDoneResponse{}, resp, "already exists response")
+
+	api.On("MessagePlugin", request).
+		Return(&StreamingErrorResponse{PluginError: janus_plugins.PluginError{Code: 456, Reason: "Port already in use"}}, nil).Once()
+	_, err = MessagePlugin(api, request)
+	assert.Error(t, err, "can't create")
+}
+
+func TestCreateMountpointStepsExists(t *testing.T) {
+	mountpoint := &models.Mountpoint{
+		Name:        "main",
+		GatewayUID:  10,
+		Audio:       true,
+		AudioPort:   null.IntFrom(5002),
+		AudioPT:     null.IntFrom(111),
+		AudioRtpmap: null.StringFrom("opus/48000/2"),
+	}
+	steps := CreateMountpointSteps(mountpoint)
+	action := func(name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.ActionName() == name
+		})
+	}
+	exists := &StreamingErrorResponse{PluginError: janus_plugins.PluginError{Code: 456, Reason: "A stream with the provided ID already exists"}}
+
+	gateway := &models.Gateway{ID: -10, Name: "same"}
+	api := new(mocks.AdminAPI)
+	api.On("MessagePlugin", action("create")).Return(exists, nil).Once()
+	api.On("MessagePlugin", mock.MatchedBy(func(r *StreamingInfoRequest) bool {
+		return r.ID == 10
+	})).Return(&StreamingInfoResponse{Info: &StreamingMountpointInfo{
+		ID:          10,
+		AudioPort:   5002,
+		AudioPT:     111,
+		AudioRtpmap: "opus/48000/2",
+	}}, nil).Once()
+	GatewayAdminAPIRegistry.Set(gateway, api)
+
+	saga := NewGatewaysSaga([]*models.Gateway{gateway}, steps...)
+	assert.NoError(t, saga.Run(), "same settings")
+	saga.Compensate()
+	assert.Equal(t, GatewayStepOK, saga.Results[0].Status, "existing mountpoint is not undone")
+	api.AssertExpectations(t)
+	api.AssertNotCalled(t, "MessagePlugin", action("destroy"))
+
+	gateway = &models.Gateway{ID: -11, Name: "drifted"}
+	api = new(mocks.AdminAPI)
+	api.On("MessagePlugin", action("create")).Return(exists, nil).Once()
+	api.On("MessagePlugin", action("info")).Return(&StreamingInfoResponse{Info: &StreamingMountpointInfo{
+		ID:          10,
+		AudioPort:   5004,
+		AudioPT:     111,
+		AudioRtpmap: "opus/48000/2",
+	}}, nil).Once()
+	GatewayAdminAPIRegistry.Set(gateway, api)
+
+	saga = NewGatewaysSaga([]*models.Gateway{gateway}, steps...)
+	assert.Error(t, saga.Run(), "other settings")
+	assert.Equal(t, GatewayStepFailed, saga.Results[0].Status, "drifted status")
+	assert.Contains(t, saga.Results[0].Error, "audioport 5004 != 5002", "drifted error")
+	api.AssertExpectations(t)
+	api.AssertNotCalled(t, "MessagePlugin", action("destroy"))
+}
+
+func TestMessagePluginKick(t *testing.T) {
+	request := NewVideoroomKickRequest("", 1234, 5678, "")
+
+	api := new(mocks.AdminAPI)
+	api.On("MessagePlugin", request).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 428, Reason: "No such feed"}}, nil).Once()
+	resp, err := MessagePlugin(api, request)
+	assert.NoError(t, err, "no such feed")
+	assert.IsType(t, &AlreadyDoneResponse{}, resp, "no such feed response")
+
+	api.On("MessagePlugin", request).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 433, Reason: "Unauthorized"}}, nil).Once()
+	_, err = MessagePlugin(api, request)
+	var vErr *janus_plugins.VideoroomErrorResponse
+	assert.True(t, errors.As(err, &vErr), "unauthorized")
+}
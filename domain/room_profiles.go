@@ -0,0 +1,65 @@
+package domain
+
+import (
+	pkgerr "github.com/pkg/errors"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+// DefaultRoomProfile holds the videoroom settings of rooms without a profile
+var DefaultRoomProfile = &models.RoomProfile{
+	Name:               "default",
+	Publishers:         100,
+	Bitrate:            64000,
+	FirFreq:            10,
+	Audiocodec:         "opus",
+	Videocodec:         "h264",
+	H264Profile:        null.StringFrom("42e01f"),
+	OpusFec:            true,
+	AudiolevelExt:      true,
+	AudiolevelEvent:    true,
+	AudioActivePackets: null.IntFrom(25),
+	AudioLevelAverage:  null.IntFrom(100),
+	VideoorientExt:     true,
+	PlayoutdelayExt:    true,
+	TransportWideCCExt: true,
+}
+
+// RoomProfiles are room profiles by id
+type RoomProfiles map[int64]*models.RoomProfile
+
+func LoadRoomProfiles(exec boil.Executor) (RoomProfiles, error) {
+	profiles, err := models.RoomProfiles().All(exec)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch room profiles")
+	}
+
+	byID := make(RoomProfiles, len(profiles))
+	for _, profile := range profiles {
+		byID[profile.ID] = profile
+	}
+	return byID, nil
+}
+
+// For returns the profile of the room, DefaultRoomProfile if it has none
+func (p RoomProfiles) For(room *models.Room) *models.RoomProfile {
+	if profile, ok := p[room.RoomProfileID.Int64]; ok && room.RoomProfileID.Valid {
+		return profile
+	}
+	return DefaultRoomProfile
+}
+
+// FindRoomProfile returns the profile of the room, DefaultRoomProfile if it has none
+func FindRoomProfile(exec boil.Executor, room *models.Room) (*models.RoomProfile, error) {
+	if !room.RoomProfileID.Valid {
+		return DefaultRoomProfile, nil
+	}
+
+	profile, err := models.FindRoomProfile(exec, room.RoomProfileID.Int64)
+	if err != nil {
+		return nil, pkgerr.Wrapf(err, "db fetch room profile %d", room.RoomProfileID.Int64)
+	}
+	return profile, nil
+}
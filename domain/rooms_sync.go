@@ -0,0 +1,302 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	janus_plugins "github.com/edoshor/janus-go/plugins"
+	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+const (
+	RoomsSyncCreate  = "create"
+	RoomsSyncEdit    = "edit"
+	RoomsSyncDestroy = "destroy"
+	RoomsSyncUnknown = "unknown" // on gateway but not in DB, left untouched
+)
+
+// RoomsSyncAction is a single difference between a gateway's videorooms or textrooms and the rooms table
+type RoomsSyncAction struct {
+	Gateway string `json:"gateway"`
+	Plugin  string `json:"plugin"`
+	Room    int    `json:"room"`
+	Action  string `json:"action"`
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type RoomsSyncReport struct {
+	DryRun   bool               `json:"dry_run"`
+	Gateways map[string]string  `json:"gateways"` // gateway name => status
+	Actions  []*RoomsSyncAction `json:"actions"`
+}
+
+// Failed counts the gateways which couldn't be synced and the actions which failed
+func (r *RoomsSyncReport) Failed() int {
+	failed := 0
+	for _, status := range r.Gateways {
+		if status != "ok" {
+			failed++
+		}
+	}
+	for _, action := range r.Actions {
+		if action.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// RoomsSyncer reconciles the videorooms and textrooms on rooms gateways with the rooms table.
+// Missing rooms are created, rooms with drifted settings are edited and
+// rooms removed from the DB are destroyed. Rooms unknown to the DB are only reported.
+type RoomsSyncer struct {
+	db     common.DBInterface
+	ticker *time.Ticker
+	stop   chan struct{}
+	done   chan struct{}
+	lock   sync.Mutex
+}
+
+func NewRoomsSyncer(db common.DBInterface) *RoomsSyncer {
+	return &RoomsSyncer{
+		db:   db,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+func (s *RoomsSyncer) Start() {
+	if common.Config.RoomsSyncInterval <= 0 {
+		return
+	}
+
+	log.Info().Msg("periodically syncing gateways rooms")
+	s.ticker = time.NewTicker(common.Config.RoomsSyncInterval)
+	go s.run()
+}
+
+// Close stops periodic syncing and waits for a running sync to finish
+func (s *RoomsSyncer) Close() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+		close(s.stop)
+		<-s.done
+	}
+}
+
+func (s *RoomsSyncer) run() {
+	defer close(s.done)
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.ticker.C:
+			report, err := s.Sync(false)
+			if err != nil {
+				log.Error().Err(err).Msg("RoomsSyncer.Sync")
+				continue
+			}
+			for _, action := range report.Actions {
+				log.Info().
+					Str("gateway", action.Gateway).
+					Int("room", action.Room).
+					Str("action", action.Action).
+					Str("reason", action.Reason).
+					Str("error", action.Error).
+					Msg("RoomsSyncer action")
+			}
+		}
+	}
+}
+
+// Sync diffs the videorooms and textrooms of all enabled rooms gateways against the rooms table and applies the differences.
+// With dryRun nothing is changed on gateways, the report says what would have been done.
+func (s *RoomsSyncer) Sync(dryRun bool) (*RoomsSyncReport, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	gateways, err := models.Gateways(
+		models.GatewayWhere.Type.EQ(common.GatewayTypeRooms),
+		models.GatewayWhere.Disabled.EQ(false),
+		models.GatewayWhere.RemovedAt.IsNull(),
+	).All(s.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch gateways")
+	}
+
+	rooms, err := models.Rooms().All(s.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch rooms")
+	}
+	profiles, err := LoadRoomProfiles(s.db)
+	if err != nil {
+		return nil, err
+	}
+	roomsByUID := make(map[int]*models.Room, len(rooms))
+	for _, room := range rooms {
+		roomsByUID[room.GatewayUID] = room
+	}
+
+	report := &RoomsSyncReport{
+		DryRun:   dryRun,
+		Gateways: make(map[string]string, len(gateways)),
+		Actions:  make([]*RoomsSyncAction, 0),
+	}
+	for _, gateway := range gateways {
+		actions, err := s.syncGateway(gateway, roomsByUID, profiles, dryRun)
+		if err != nil {
+			report.Gateways[gateway.Name] = err.Error()
+			continue
+		}
+		report.Gateways[gateway.Name] = "ok"
+		report.Actions = append(report.Actions, actions...)
+	}
+
+	return report, nil
+}
+
+func (s *RoomsSyncer) syncGateway(gateway *models.Gateway, roomsByUID map[int]*models.Room, profiles RoomProfiles, dryRun bool) ([]*RoomsSyncAction, error) {
+	api, err := GatewayAdminAPIRegistry.For(gateway)
+	if err != nil {
+		return nil, pkgerr.WithMessage(err, "Admin API for gateway")
+	}
+
+	vrFactory := janus_plugins.MakeVideoroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	resp, err := api.MessagePlugin(vrFactory.ListRequest())
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "api.MessagePlugin [videoroom list]")
+	}
+	vList, ok := resp.(*janus_plugins.VideoroomListResponse)
+	if !ok {
+		return nil, pkgerr.Errorf("unexpected videoroom list response: %v", resp)
+	}
+
+	trFactory := janus_plugins.MakeTextroomRequestFactory(common.Config.GatewayPluginAdminKey)
+	resp, err = api.MessagePlugin(trFactory.ListRequest())
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "api.MessagePlugin [textroom list]")
+	}
+	tList, ok := resp.(*janus_plugins.TextroomListResponse)
+	if !ok {
+		return nil, pkgerr.Errorf("unexpected textroom list response: %v", resp)
+	}
+
+	vrDrift := make(map[int]string, len(vList.Rooms))
+	for _, vRoom := range vList.Rooms {
+		if room, ok := roomsByUID[vRoom.Room]; ok {
+			vrDrift[vRoom.Room] = videoroomDrift(room, profiles.For(room), vRoom)
+		} else {
+			vrDrift[vRoom.Room] = ""
+		}
+	}
+	trDrift := make(map[int]string, len(tList.Rooms))
+	for _, tRoom := range tList.Rooms {
+		if room, ok := roomsByUID[tRoom.Room]; ok {
+			trDrift[tRoom.Room] = textroomDrift(room, tRoom)
+		} else {
+			trDrift[tRoom.Room] = ""
+		}
+	}
+
+	actions := append(
+		diffRooms("janus.plugin.videoroom", vrDrift, roomsByUID),
+		diffRooms("janus.plugin.textroom", trDrift, roomsByUID)...,
+	)
+
+	sort.Slice(actions, func(i, j int) bool {
+		if actions[i].Room != actions[j].Room {
+			return actions[i].Room < actions[j].Room
+		}
+		return actions[i].Plugin > actions[j].Plugin // videoroom first
+	})
+
+	for _, action := range actions {
+		action.Gateway = gateway.Name
+		if dryRun {
+			continue
+		}
+
+		room := roomsByUID[action.Room]
+		var request janus_plugins.PluginRequest
+		switch {
+		case action.Action == RoomsSyncCreate && action.Plugin == "janus.plugin.videoroom":
+			request = vrFactory.CreateRequest(NewVideoroomRoom(room.GatewayUID, room.Name, profiles.For(room)), true, nil)
+		case action.Action == RoomsSyncCreate:
+			request = trFactory.CreateRequest(NewTextroomRoom(room.GatewayUID, room.Name), true, nil)
+		case action.Action == RoomsSyncEdit && action.Plugin == "janus.plugin.videoroom":
+			request = vrFactory.EditRequest(NewVideoroomRoomForEdit(room.GatewayUID, room.Name, profiles.For(room)), true, common.Config.GatewayRoomsSecret)
+		case action.Action == RoomsSyncEdit:
+			request = trFactory.EditRequest(NewTextroomRoomForEdit(room.GatewayUID, room.Name), true, common.Config.GatewayRoomsSecret)
+		case action.Action == RoomsSyncDestroy && action.Plugin == "janus.plugin.videoroom":
+			request = vrFactory.DestroyRequest(action.Room, true, common.Config.GatewayRoomsSecret)
+		case action.Action == RoomsSyncDestroy:
+			request = trFactory.DestroyRequest(action.Room, true, common.Config.GatewayRoomsSecret)
+		default:
+			continue
+		}
+
+		if _, err := MessagePlugin(api, request); err != nil {
+			action.Error = err.Error()
+		}
+	}
+
+	return actions, nil
+}
+
+// diffRooms returns the actions bringing a plugin's rooms on a gateway in line with the rooms table.
+// onGateway is the drift of every room on the gateway, empty if none.
+func diffRooms(plugin string, onGateway map[int]string, roomsByUID map[int]*models.Room) []*RoomsSyncAction {
+	actions := make([]*RoomsSyncAction, 0)
+	for uid, drift := range onGateway {
+		room, ok := roomsByUID[uid]
+		switch {
+		case !ok:
+			actions = append(actions, &RoomsSyncAction{Plugin: plugin, Action: RoomsSyncUnknown, Room: uid})
+		case room.RemovedAt.Valid:
+			actions = append(actions, &RoomsSyncAction{Plugin: plugin, Action: RoomsSyncDestroy, Room: uid, Reason: "room removed"})
+		case drift != "":
+			actions = append(actions, &RoomsSyncAction{Plugin: plugin, Action: RoomsSyncEdit, Room: uid, Reason: drift})
+		}
+	}
+
+	for uid, room := range roomsByUID {
+		if _, ok := onGateway[uid]; !ok && !room.RemovedAt.Valid {
+			actions = append(actions, &RoomsSyncAction{Plugin: plugin, Action: RoomsSyncCreate, Room: uid, Reason: "missing on gateway"})
+		}
+	}
+
+	return actions
+}
+
+// videoroomDrift describes how a gateway videoroom differs from what we create for the room, if at all.
+// Only editable settings are compared.
+func videoroomDrift(room *models.Room, profile *models.RoomProfile, vRoom *janus_plugins.VideoroomRoomFromListResponse) string {
+	if vRoom.Description != room.Name {
+		return fmt.Sprintf("description %q != %q", vRoom.Description, room.Name)
+	}
+	if vRoom.MaxPublishers != profile.Publishers {
+		return fmt.Sprintf("publishers %d != %d", vRoom.MaxPublishers, profile.Publishers)
+	}
+	if vRoom.Bitrate != profile.Bitrate {
+		return fmt.Sprintf("bitrate %d != %d", vRoom.Bitrate, profile.Bitrate)
+	}
+	if vRoom.FirFreq != profile.FirFreq {
+		return fmt.Sprintf("fir_freq %d != %d", vRoom.FirFreq, profile.FirFreq)
+	}
+	return ""
+}
+
+// textroomDrift describes how a gateway textroom differs from what we create for the room, if at all
+func textroomDrift(room *models.Room, tRoom *janus_plugins.TextroomRoomFromListResponse) string {
+	if tRoom.Description != room.Name {
+		return fmt.Sprintf("description %q != %q", tRoom.Description, room.Name)
+	}
+	return ""
+}
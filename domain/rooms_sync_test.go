@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	janus_plugins "github.com/edoshor/janus-go/plugins"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/testutil/mocks"
+)
+
+type RoomsSyncTestSuite struct {
+	ModelsSuite
+}
+
+func (s *RoomsSyncTestSuite) SetupSuite() {
+	s.Require().NoError(s.InitTestDB())
+}
+
+func (s *RoomsSyncTestSuite) TearDownSuite() {
+	s.Require().NoError(s.DestroyTestDB())
+}
+
+func (s *RoomsSyncTestSuite) SetupTest() {
+	s.DBCleaner.Acquire(s.AllTables()...)
+}
+
+func (s *RoomsSyncTestSuite) TearDownTest() {
+	s.DBCleaner.Clean(s.AllTables()...)
+}
+
+func (s *RoomsSyncTestSuite) TestSync() {
+	gateway := s.CreateGateway()
+	inSync := s.CreateRoom(gateway)
+	missing := s.CreateRoom(gateway)
+	drifted := s.CreateRoom(gateway)
+	removed := s.CreateRoom(gateway)
+	profiled := s.CreateRoom(gateway)
+	profile := *DefaultRoomProfile
+	profile.Name = "conference"
+	profile.Publishers = 200
+	s.Require().NoError(profile.Insert(s.DB, boil.Infer()))
+	profiled.RoomProfileID = null.Int64From(profile.ID)
+	_, err := profiled.Update(s.DB, boil.Whitelist(models.RoomColumns.RoomProfileID))
+	s.Require().NoError(err)
+	removed.RemovedAt = null.TimeFrom(time.Now().UTC())
+	_, err = removed.Update(s.DB, boil.Whitelist(models.RoomColumns.RemovedAt))
+	s.Require().NoError(err)
+
+	vRoom := func(room *models.Room, description string) *janus_plugins.VideoroomRoomFromListResponse {
+		return &janus_plugins.VideoroomRoomFromListResponse{
+			VideoroomRoom: janus_plugins.VideoroomRoom{
+				Room:        room.GatewayUID,
+				Description: description,
+				Bitrate:     DefaultRoomProfile.Bitrate,
+				FirFreq:     DefaultRoomProfile.FirFreq,
+			},
+			MaxPublishers: DefaultRoomProfile.Publishers,
+		}
+	}
+	unknown := &models.Room{GatewayUID: -1}
+	listResponse := &janus_plugins.VideoroomListResponse{
+		Rooms: []*janus_plugins.VideoroomRoomFromListResponse{
+			vRoom(inSync, inSync.Name),
+			vRoom(drifted, "old name"),
+			vRoom(removed, removed.Name),
+			vRoom(profiled, profiled.Name),
+			vRoom(unknown, "unknown"),
+		},
+	}
+
+	// textrooms: drifted one was renamed, profiled one predates textrooms
+	tRoom := func(room *models.Room, description string) *janus_plugins.TextroomRoomFromListResponse {
+		return &janus_plugins.TextroomRoomFromListResponse{
+			TextroomRoom: janus_plugins.TextroomRoom{Room: room.GatewayUID, Description: description},
+		}
+	}
+	tListResponse := &janus_plugins.TextroomListResponse{
+		Rooms: []*janus_plugins.TextroomRoomFromListResponse{
+			tRoom(inSync, inSync.Name),
+			tRoom(drifted, "old name"),
+			tRoom(removed, removed.Name),
+		},
+	}
+
+	janusAdminAPI := new(mocks.AdminAPI)
+	action := func(plugin, name string) interface{} {
+		return mock.MatchedBy(func(r janus_plugins.PluginRequest) bool {
+			return r.PluginName() == plugin && r.ActionName() == name
+		})
+	}
+	const vr, tr = "janus.plugin.videoroom", "janus.plugin.textroom"
+	janusAdminAPI.On("MessagePlugin", action(vr, "list")).Return(listResponse, nil)
+	janusAdminAPI.On("MessagePlugin", action(tr, "list")).Return(tListResponse, nil)
+	GatewayAdminAPIRegistry.Set(gateway, janusAdminAPI)
+
+	type key struct {
+		plugin string
+		room   int
+	}
+	expected := map[key]string{
+		{vr, unknown.GatewayUID}:  RoomsSyncUnknown,
+		{vr, missing.GatewayUID}:  RoomsSyncCreate,
+		{vr, drifted.GatewayUID}:  RoomsSyncEdit,
+		{vr, removed.GatewayUID}:  RoomsSyncDestroy,
+		{vr, profiled.GatewayUID}: RoomsSyncEdit,
+		{tr, missing.GatewayUID}:  RoomsSyncCreate,
+		{tr, drifted.GatewayUID}:  RoomsSyncEdit,
+		{tr, removed.GatewayUID}:  RoomsSyncDestroy,
+		{tr, profiled.GatewayUID}: RoomsSyncCreate,
+	}
+
+	report, err := NewRoomsSyncer(s.DB).Sync(true)
+	s.Require().NoError(err)
+	s.True(report.DryRun, "dry run")
+	s.Equal("ok", report.Gateways[gateway.Name], "gateway status")
+	s.Require().Len(report.Actions, len(expected), "actions")
+	for _, a := range report.Actions {
+		s.Equalf(expected[key{a.Plugin, a.Room}], a.Action, "%s room %d", a.Plugin, a.Room)
+		s.Equal(gateway.Name, a.Gateway, "gateway")
+	}
+	janusAdminAPI.AssertNumberOfCalls(s.T(), "MessagePlugin", 2)
+
+	janusAdminAPI.On("MessagePlugin", action(vr, "create")).Return(&janus_plugins.VideoroomCreateResponse{}, nil).Once()
+	janusAdminAPI.On("MessagePlugin", action(vr, "edit")).Return(&janus_plugins.VideoroomEditResponse{}, nil).Twice()
+	janusAdminAPI.On("MessagePlugin", action(vr, "destroy")).
+		Return(&janus_plugins.VideoroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 426, Reason: "No such room"}}, nil).Once()
+	janusAdminAPI.On("MessagePlugin", action(tr, "create")).Return(&janus_plugins.TextroomCreateResponse{}, nil).Twice()
+	janusAdminAPI.On("MessagePlugin", action(tr, "edit")).Return(&janus_plugins.TextroomEditResponse{}, nil).Once()
+	janusAdminAPI.On("MessagePlugin", action(tr, "destroy")).
+		Return(&janus_plugins.TextroomErrorResponse{PluginError: janus_plugins.PluginError{Code: 419, Reason: "Unauthorized"}}, nil).Once()
+
+	report, err = NewRoomsSyncer(s.DB).Sync(false)
+	s.Require().NoError(err)
+	s.False(report.DryRun, "dry run")
+	s.Require().Len(report.Actions, len(expected), "actions")
+	for _, a := range report.Actions {
+		if a.Plugin == tr && a.Action == RoomsSyncDestroy {
+			s.Equal("Unauthorized", a.Error, "textroom destroy error")
+		} else {
+			s.Emptyf(a.Error, "%s room %d error", a.Plugin, a.Room) // destroying a room which is already gone is fine
+		}
+	}
+	s.Equal(1, report.Failed(), "failed")
+	janusAdminAPI.AssertExpectations(s.T())
+}
+
+func (s *RoomsSyncTestSuite) TestClose() {
+	interval := common.Config.RoomsSyncInterval
+	common.Config.RoomsSyncInterval = time.Hour
+	defer func() { common.Config.RoomsSyncInterval = interval }()
+
+	syncer := NewRoomsSyncer(s.DB)
+	syncer.Start()
+
+	closed := make(chan struct{})
+	go func() {
+		syncer.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		s.FailNow("timeout waiting for Close")
+	}
+}
+
+func TestRoomsSyncTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomsSyncTestSuite))
+}
@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	pkgerr "github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+	"github.com/volatiletech/sqlboiler/queries"
+	"github.com/volatiletech/sqlboiler/queries/qm"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/sqlutil"
+	"github.com/Bnei-Baruch/gxydb-api/pkg/stringutil"
+)
+
+// UserData is everything we hold about a single user
+type UserData struct {
+	User            *models.User                `json:"user"`
+	Sessions        models.SessionSlice         `json:"sessions"`
+	SessionEvents   models.SessionEventSlice    `json:"session_events"`
+	Questions       models.QuestionSlice        `json:"questions"`
+	DailyAttendance models.DailyAttendanceSlice `json:"daily_attendance"`
+	Bans            models.UserBanSlice         `json:"bans"`
+	ExportedAt      time.Time                   `json:"exported_at"`
+}
+
+// ErasedAccountsIDPrefix marks the accounts_id of erased users
+const ErasedAccountsIDPrefix = "erased-"
+
+// ErasedDisplay replaces the session displays of erased users
+const ErasedDisplay = "erased"
+
+type UserDataManager struct {
+	db common.DBInterface
+}
+
+func NewUserDataManager(db common.DBInterface) *UserDataManager {
+	return &UserDataManager{
+		db: db,
+	}
+}
+
+// Export collects all data held about the user with the given accounts id.
+// It returns sql.ErrNoRows if there is no such user.
+func (m *UserDataManager) Export(accountsID string) (*UserData, error) {
+	user, err := models.Users(models.UserWhere.AccountsID.EQ(accountsID)).One(m.db)
+	if err != nil {
+		return nil, pkgerr.WithStack(err)
+	}
+
+	data := &UserData{
+		User:       user,
+		ExportedAt: time.Now().UTC(),
+	}
+
+	data.Sessions, err = models.Sessions(
+		models.SessionWhere.UserID.EQ(user.ID),
+		qm.OrderBy("created_at asc")).All(m.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch sessions")
+	}
+
+	data.SessionEvents, err = models.SessionEvents(
+		models.SessionEventWhere.UserID.EQ(user.ID),
+		qm.OrderBy("id asc")).All(m.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch session events")
+	}
+
+	data.Questions, err = models.Questions(
+		models.QuestionWhere.UserID.EQ(user.ID),
+		qm.OrderBy("raised_at asc")).All(m.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch questions")
+	}
+
+	data.DailyAttendance, err = models.DailyAttendances(
+		models.DailyAttendanceWhere.UserID.EQ(user.ID),
+		qm.OrderBy("date asc")).All(m.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch daily attendance")
+	}
+
+	data.Bans, err = models.UserBans(
+		models.UserBanWhere.UserID.EQ(user.ID),
+		qm.OrderBy("created_at asc")).All(m.db)
+	if err != nil {
+		return nil, pkgerr.Wrap(err, "db fetch bans")
+	}
+
+	return data, nil
+}
+
+// Erase anonymizes the user with the given accounts id.
+// Everything held about the user is moved to a new anonymous user row and the original row is deleted,
+// so nothing links back to the person while aggregate counts stay intact.
+// Session IPs, user agents and extra data are removed, displays are replaced with ErasedDisplay and
+// free text comments on kicks and bans are dropped.
+// It returns the anonymous user or sql.ErrNoRows if there is no such user.
+func (m *UserDataManager) Erase(ctx context.Context, accountsID string) (*models.User, error) {
+	var anonymous *models.User
+	err := sqlutil.InTx(ctx, m.db, func(tx *sql.Tx) error {
+		user, err := models.Users(
+			models.UserWhere.AccountsID.EQ(accountsID),
+			qm.For("update")).One(tx)
+		if err != nil {
+			return pkgerr.WithStack(err)
+		}
+
+		now := time.Now().UTC()
+		anonymous = &models.User{
+			AccountsID: ErasedAccountsIDPrefix + stringutil.GenerateName(36-len(ErasedAccountsIDPrefix)),
+			Disabled:   true,
+			RemovedAt:  null.TimeFrom(now),
+		}
+		if err := anonymous.Insert(tx, boil.Infer()); err != nil {
+			return pkgerr.Wrap(err, "db insert anonymous user")
+		}
+
+		res, err := queries.Raw(`update sessions
+set user_id    = $1,
+    display    = $2,
+    ip_address = null,
+    user_agent = null,
+    extra      = null,
+    properties = properties - 'comment'
+where user_id = $3`, anonymous.ID, ErasedDisplay, user.ID).Exec(tx)
+		if err != nil {
+			return pkgerr.Wrap(err, "db anonymize sessions")
+		}
+		affected, _ := res.RowsAffected()
+
+		if _, err := queries.Raw(`update session_events
+set user_id    = $1,
+    properties = properties - 'comment'
+where user_id = $2`, anonymous.ID, user.ID).Exec(tx); err != nil {
+			return pkgerr.Wrap(err, "db anonymize session events")
+		}
+
+		// ban reasons may carry a free text comment, e.g. "kick: <comment>"
+		if _, err := queries.Raw(`update user_bans
+set user_id = $1,
+    reason  = case when reason like 'kick%' then 'kick' end
+where user_id = $2`, anonymous.ID, user.ID).Exec(tx); err != nil {
+			return pkgerr.Wrap(err, "db anonymize bans")
+		}
+
+		for _, table := range []string{"questions", "daily_attendance"} {
+			if _, err := queries.Raw(fmt.Sprintf("update %s set user_id = $1 where user_id = $2", table),
+				anonymous.ID, user.ID).Exec(tx); err != nil {
+				return pkgerr.Wrapf(err, "db detach %s", table)
+			}
+		}
+
+		if _, err := user.Delete(tx); err != nil {
+			return pkgerr.Wrap(err, "db delete user")
+		}
+
+		log.Ctx(ctx).Info().
+			Int64("user", user.ID).
+			Int64("anonymous", anonymous.ID).
+			Int64("sessions", affected).
+			Msg("user data erased")
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return anonymous, nil
+}
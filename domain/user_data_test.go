@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/volatiletech/null"
+	"github.com/volatiletech/sqlboiler/boil"
+
+	"github.com/Bnei-Baruch/gxydb-api/common"
+	"github.com/Bnei-Baruch/gxydb-api/models"
+)
+
+type UserDataTestSuite struct {
+	ModelsSuite
+}
+
+func (s *UserDataTestSuite) SetupSuite() {
+	s.Require().NoError(s.InitTestDB())
+}
+
+func (s *UserDataTestSuite) TearDownSuite() {
+	s.Require().NoError(s.DestroyTestDB())
+}
+
+func (s *UserDataTestSuite) SetupTest() {
+	s.DBCleaner.Acquire(s.AllTables()...)
+}
+
+func (s *UserDataTestSuite) TearDownTest() {
+	s.DBCleaner.Clean(s.AllTables()...)
+}
+
+func (s *UserDataTestSuite) TestExport() {
+	udm := NewUserDataManager(s.DB)
+
+	_, err := udm.Export("unknown")
+	s.True(errors.Is(err, sql.ErrNoRows), "unknown user")
+
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	other := s.CreateUser()
+	sessions := []*models.Session{s.CreateSession(user, gateway, room), s.CreateSession(user, gateway, room)}
+	s.CreateSession(other, gateway, room)
+	event := &models.SessionEvent{
+		SessionID: sessions[0].ID,
+		UserID:    user.ID,
+		Type:      common.SessionEventEnter,
+		Source:    common.SessionEventSourceProtocol,
+	}
+	s.Require().NoError(event.Insert(s.DB, boil.Infer()))
+	ban := &models.UserBan{UserID: user.ID, Until: time.Now().UTC()}
+	s.Require().NoError(ban.Insert(s.DB, boil.Infer()))
+
+	data, err := udm.Export(user.AccountsID)
+	s.Require().NoError(err)
+	s.Equal(user.ID, data.User.ID, "user")
+	s.Len(data.Sessions, 2, "sessions")
+	s.Equal(sessions[0].ID, data.Sessions[0].ID, "first session")
+	s.Len(data.SessionEvents, 1, "session events")
+	s.Empty(data.Questions, "questions")
+	s.Empty(data.DailyAttendance, "daily attendance")
+	s.Len(data.Bans, 1, "bans")
+}
+
+func (s *UserDataTestSuite) TestErase() {
+	udm := NewUserDataManager(s.DB)
+
+	_, err := udm.Erase(context.TODO(), "unknown")
+	s.True(errors.Is(err, sql.ErrNoRows), "unknown user")
+
+	gateway := s.CreateGateway()
+	room := s.CreateRoom(gateway)
+	user := s.CreateUser()
+	other := s.CreateUser()
+	session := s.CreateSession(user, gateway, room)
+	session.Extra = null.JSONFrom([]byte(`{"key": "value"}`))
+	_, err = session.Update(s.DB, boil.Whitelist(models.SessionColumns.Extra))
+	s.Require().NoError(err)
+	otherSession := s.CreateSession(other, gateway, room)
+	event := &models.SessionEvent{
+		SessionID:  session.ID,
+		UserID:     user.ID,
+		Type:       common.SessionEventClose,
+		Source:     common.SessionEventSourceAdmin,
+		Properties: null.JSONFrom([]byte(`{"reason": "kick", "comment": "personal"}`)),
+	}
+	s.Require().NoError(event.Insert(s.DB, boil.Infer()))
+	ban := &models.UserBan{UserID: user.ID, Reason: null.StringFrom("kick: personal"), Until: time.Now().UTC()}
+	s.Require().NoError(ban.Insert(s.DB, boil.Infer()))
+
+	erased, err := udm.Erase(context.TODO(), user.AccountsID)
+	s.Require().NoError(err)
+	s.NotEqual(user.ID, erased.ID, "user id")
+	exists, err := models.UserExists(s.DB, user.ID)
+	s.Require().NoError(err)
+	s.False(exists, "original user row")
+	s.True(strings.HasPrefix(erased.AccountsID, ErasedAccountsIDPrefix), "accounts_id")
+	s.Len(erased.AccountsID, 36, "accounts_id length")
+	s.False(erased.Email.Valid, "email")
+	s.False(erased.FirstName.Valid, "first_name")
+	s.False(erased.LastName.Valid, "last_name")
+	s.False(erased.Username.Valid, "username")
+	s.True(erased.RemovedAt.Valid, "removed_at")
+
+	s.Require().NoError(session.Reload(s.DB))
+	s.Equal(erased.ID, session.UserID, "session user")
+	s.False(session.IPAddress.Valid, "ip_address")
+	s.False(session.UserAgent.Valid, "user_agent")
+	s.False(session.Extra.Valid, "extra")
+	s.Equal(ErasedDisplay, session.Display.String, "display")
+
+	s.Require().NoError(event.Reload(s.DB))
+	s.Equal(erased.ID, event.UserID, "event user")
+	s.JSONEq(`{"reason": "kick"}`, string(event.Properties.JSON), "event properties")
+
+	s.Require().NoError(ban.Reload(s.DB))
+	s.Equal(erased.ID, ban.UserID, "ban user")
+	s.Equal("kick", ban.Reason.String, "ban reason")
+
+	s.Require().NoError(otherSession.Reload(s.DB))
+	s.True(otherSession.IPAddress.Valid, "other ip_address")
+	s.Equal(other.Username.String, otherSession.Display.String, "other display")
+
+	_, err = udm.Export(user.AccountsID)
+	s.True(errors.Is(err, sql.ErrNoRows), "erased user")
+}
+
+func TestUserDataTestSuite(t *testing.T) {
+	suite.Run(t, new(UserDataTestSuite))
+}
@@ -13,16 +13,21 @@ import (
 	"github.com/Bnei-Baruch/gxydb-api/models"
 )
 
+// PeriodicCollector probes gateways every second, feeding domain.GatewayHealthRegistry.
+// Room participants stats are only collected if collectStats.
+// Gateways are probed regardless as their health is needed by client config and gateway assignment.
 type PeriodicCollector struct {
-	ticker *time.Ticker
-	ticks  int64
-	db     common.DBInterface
+	ticker       *time.Ticker
+	ticks        int64
+	db           common.DBInterface
+	collectStats bool
 }
 
-func NewPeriodicCollector(db common.DBInterface) *PeriodicCollector {
+func NewPeriodicCollector(db common.DBInterface, collectStats bool) *PeriodicCollector {
 	return &PeriodicCollector{
-		ticker: time.NewTicker(time.Second),
-		db:     db,
+		ticker:       time.NewTicker(time.Second),
+		db:           db,
+		collectStats: collectStats,
 	}
 }
 
@@ -31,7 +36,11 @@ func (pc *PeriodicCollector) Start() {
 		pc.ticker.Stop()
 	}
 
-	log.Info().Msg("periodically collecting stats")
+	if pc.collectStats {
+		log.Info().Msg("periodically collecting stats")
+	} else {
+		log.Info().Msg("periodically probing gateways health, stats collection is disabled")
+	}
 	pc.ticker = time.NewTicker(time.Second)
 	go pc.run()
 }
@@ -45,7 +54,9 @@ func (pc *PeriodicCollector) Close() {
 func (pc *PeriodicCollector) run() {
 	for range pc.ticker.C {
 		pc.ticks++
-		pc.collectRoomParticipants()
+		if pc.collectStats {
+			pc.collectRoomParticipants()
+		}
 		pc.collectGatewaySessions()
 	}
 }
@@ -78,9 +89,12 @@ func (pc *PeriodicCollector) collectRoomParticipants() {
 	}
 }
 
+// gatewayProbeTimeout keeps probing all gateways within a single tick
+const gatewayProbeTimeout = 900 * time.Millisecond
+
 type gatewayCallRes struct {
 	gateway  *models.Gateway
-	sessions int
+	sessions []uint64
 	duration time.Duration
 	err      error
 }
@@ -96,8 +110,11 @@ func (pc *PeriodicCollector) collectGatewaySessions() {
 	}
 
 	Stats.GatewaySessionsGauge.Reset()
+	Stats.GatewayHealthyGauge.Reset()
+	Stats.GatewayLatencyGauge.Reset()
 
-	c := make(chan *gatewayCallRes)
+	// buffered so late responses after timeout don't block
+	c := make(chan *gatewayCallRes, len(gateways))
 
 	for _, gateway := range gateways {
 		go func(g *models.Gateway, c chan *gatewayCallRes) {
@@ -126,25 +143,49 @@ func (pc *PeriodicCollector) collectGatewaySessions() {
 				return
 			}
 
-			res.sessions = len(tApiRes.Sessions)
+			res.sessions = tApiRes.Sessions
 		}(gateway, c)
 	}
 
-	timeout := time.After(900 * time.Millisecond)
+	pending := make(map[int64]*models.Gateway, len(gateways))
+	for _, gateway := range gateways {
+		pending[gateway.ID] = gateway
+	}
+
+	timeout := time.After(gatewayProbeTimeout)
 	for i := range gateways {
 		select {
 		case res := <-c:
+			delete(pending, res.gateway.ID)
 			if res.err != nil {
 				log.Error().
 					Err(res.err).
 					Dur("duration", res.duration).
 					Str("gateway", res.gateway.Name).
 					Msg("PeriodicCollector.collectGatewaySessions error")
+				domain.GatewayHealthRegistry.ReportFailure(res.gateway, res.duration, res.err)
+			} else {
+				domain.GatewayHealthRegistry.ReportSuccess(res.gateway, res.duration, res.sessions)
 			}
-			Stats.GatewaySessionsGauge.WithLabelValues(res.gateway.Name, res.gateway.Type).Set(float64(res.sessions))
+			Stats.GatewaySessionsGauge.WithLabelValues(res.gateway.Name, res.gateway.Type).Set(float64(len(res.sessions)))
+			Stats.GatewayLatencyGauge.WithLabelValues(res.gateway.Name, res.gateway.Type).Set(float64(res.duration.Milliseconds()))
 		case <-timeout:
 			log.Error().Msgf("PeriodicCollector.collectGatewaySessions timeout (i, len)=(%d,%d)", i, len(gateways))
+			for _, gateway := range pending {
+				domain.GatewayHealthRegistry.ReportFailure(gateway, gatewayProbeTimeout, pkgerr.New("timeout"))
+			}
+			pending = nil
+		}
+		if pending == nil {
 			break
 		}
 	}
+
+	for _, gateway := range gateways {
+		healthy := 0.0
+		if domain.GatewayHealthRegistry.IsHealthy(gateway) {
+			healthy = 1
+		}
+		Stats.GatewayHealthyGauge.WithLabelValues(gateway.Name, gateway.Type).Set(healthy)
+	}
 }
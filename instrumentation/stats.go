@@ -8,8 +8,12 @@ var Stats = new(Collectors)
 
 type Collectors struct {
 	GatewaySessionsGauge     *prometheus.GaugeVec
+	GatewayHealthyGauge      *prometheus.GaugeVec
+	GatewayLatencyGauge      *prometheus.GaugeVec
 	RoomParticipantsGauge    *prometheus.GaugeVec
 	RequestDurationHistogram *prometheus.HistogramVec
+	ReconcileClosedCounter   *prometheus.CounterVec
+	ReconcileUnknownGauge    *prometheus.GaugeVec
 }
 
 func (c *Collectors) Init() {
@@ -24,6 +28,28 @@ func (c *Collectors) Init() {
 		// gateway type (rooms, streaming)
 		"type"})
 
+	c.GatewayHealthyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "galaxy",
+		Subsystem: "gateways",
+		Name:      "healthy",
+		Help:      "WebRTC Gateways health (1 healthy, 0 unhealthy)",
+	}, []string{
+		// gateway name
+		"name",
+		// gateway type (rooms, streaming)
+		"type"})
+
+	c.GatewayLatencyGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "galaxy",
+		Subsystem: "gateways",
+		Name:      "latency",
+		Help:      "WebRTC Gateways admin API latency (in milliseconds)",
+	}, []string{
+		// gateway name
+		"name",
+		// gateway type (rooms, streaming)
+		"type"})
+
 	c.RoomParticipantsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "galaxy",
 		Subsystem: "api",
@@ -41,14 +67,42 @@ func (c *Collectors) Init() {
 		Help:      "Time (in milliseconds) spent serving HTTP requests.",
 	}, []string{"method", "route", "status_code"})
 
+	c.ReconcileClosedCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "galaxy",
+		Subsystem: "reconcile",
+		Name:      "closed_sessions",
+		Help:      "DB sessions closed since their gateway session or handle is gone",
+	}, []string{
+		// gateway name
+		"name",
+	})
+
+	c.ReconcileUnknownGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "galaxy",
+		Subsystem: "reconcile",
+		Name:      "unknown_sessions",
+		Help:      "Gateway sessions unknown to the DB",
+	}, []string{
+		// gateway name
+		"name",
+	})
+
 	prometheus.MustRegister(c.GatewaySessionsGauge)
+	prometheus.MustRegister(c.GatewayHealthyGauge)
+	prometheus.MustRegister(c.GatewayLatencyGauge)
 	prometheus.MustRegister(c.RoomParticipantsGauge)
 	prometheus.MustRegister(c.RequestDurationHistogram)
+	prometheus.MustRegister(c.ReconcileClosedCounter)
+	prometheus.MustRegister(c.ReconcileUnknownGauge)
 	prometheus.MustRegister(prometheus.NewBuildInfoCollector())
 }
 
 func (c *Collectors) Reset() {
 	c.GatewaySessionsGauge.Reset()
+	c.GatewayHealthyGauge.Reset()
+	c.GatewayLatencyGauge.Reset()
 	c.RoomParticipantsGauge.Reset()
 	c.RequestDurationHistogram.Reset()
+	c.ReconcileClosedCounter.Reset()
+	c.ReconcileUnknownGauge.Reset()
 }
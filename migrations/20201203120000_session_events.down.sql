@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS session_events_user_id_created_at_idx;
+DROP INDEX IF EXISTS session_events_session_id_created_at_idx;
+
+DROP TABLE IF EXISTS session_events;
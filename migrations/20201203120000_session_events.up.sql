@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS session_events;
+CREATE TABLE IF NOT EXISTS session_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    session_id BIGINT REFERENCES sessions   NOT NULL,
+    user_id    BIGINT REFERENCES users      NOT NULL,
+    type       VARCHAR(32)                  NOT NULL,
+    source     VARCHAR(32)                  NOT NULL,
+    old_value  TEXT                         NULL,
+    new_value  TEXT                         NULL,
+    properties JSONB                        NULL,
+    created_at TIMESTAMP WITH TIME ZONE     NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS session_events_session_id_created_at_idx
+    ON session_events USING BTREE (session_id, created_at);
+CREATE INDEX IF NOT EXISTS session_events_user_id_created_at_idx
+    ON session_events USING BTREE (user_id, created_at);
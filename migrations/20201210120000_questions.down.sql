@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS questions_user_id_idx;
+DROP INDEX IF EXISTS questions_room_id_status_raised_at_idx;
+
+DROP TABLE IF EXISTS questions;
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS questions;
+CREATE TABLE IF NOT EXISTS questions
+(
+    id          BIGSERIAL PRIMARY KEY,
+    room_id     BIGINT REFERENCES rooms      NOT NULL,
+    user_id     BIGINT REFERENCES users      NOT NULL,
+    session_id  BIGINT REFERENCES sessions   NULL,
+    status      VARCHAR(16)                  NOT NULL,
+    raised_at   TIMESTAMP WITH TIME ZONE     NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMP WITH TIME ZONE     NULL,
+    resolved_by VARCHAR(255)                 NULL
+);
+
+CREATE INDEX IF NOT EXISTS questions_room_id_status_raised_at_idx
+    ON questions USING BTREE (room_id, status, raised_at);
+CREATE INDEX IF NOT EXISTS questions_user_id_idx
+    ON questions USING BTREE (user_id);
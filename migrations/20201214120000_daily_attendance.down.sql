@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS daily_attendance_date_region_idx;
+DROP INDEX IF EXISTS daily_attendance_date_room_id_idx;
+
+DROP TABLE IF EXISTS daily_attendance;
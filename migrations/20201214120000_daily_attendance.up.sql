@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS daily_attendance;
+CREATE TABLE IF NOT EXISTS daily_attendance
+(
+    id            BIGSERIAL PRIMARY KEY,
+    date          DATE                         NOT NULL,
+    user_id       BIGINT REFERENCES users      NOT NULL,
+    room_id       BIGINT REFERENCES rooms      NOT NULL,
+    region        VARCHAR(32)                  NULL,
+    first_seen    TIMESTAMP WITH TIME ZONE     NOT NULL,
+    last_seen     TIMESTAMP WITH TIME ZONE     NOT NULL,
+    total_minutes INTEGER                      NOT NULL,
+    sessions      INTEGER                      NOT NULL,
+    updated_at    TIMESTAMP WITH TIME ZONE     NOT NULL DEFAULT now(),
+    UNIQUE (date, user_id, room_id)
+);
+
+CREATE INDEX IF NOT EXISTS daily_attendance_date_room_id_idx
+    ON daily_attendance USING BTREE (date, room_id);
+CREATE INDEX IF NOT EXISTS daily_attendance_date_region_idx
+    ON daily_attendance USING BTREE (date, region);
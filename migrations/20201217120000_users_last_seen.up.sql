@@ -0,0 +1,2 @@
+alter table users
+    add column last_seen_at TIMESTAMP WITH TIME ZONE null;
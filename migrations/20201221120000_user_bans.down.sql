@@ -0,0 +1,19 @@
+-- restore active kick bans as re-entry blocks in users.properties (one per user, the longest).
+-- A missing room_id blocks all rooms.
+UPDATE users u
+SET properties = coalesce(u.properties, '{}'::jsonb) || jsonb_build_object('reentry_block', jsonb_strip_nulls(jsonb_build_object(
+        'room_id', b.room_id,
+        'by', b.banned_by,
+        'until', b.until)))
+FROM (SELECT DISTINCT ON (user_id) user_id, room_id, banned_by, until
+      FROM user_bans
+      WHERE lifted_at IS NULL
+        AND until > now()
+        AND (reason = 'kick' OR reason LIKE 'kick:%')
+      ORDER BY user_id, until DESC) b
+WHERE u.id = b.user_id;
+
+DROP INDEX IF EXISTS user_bans_until_idx;
+DROP INDEX IF EXISTS user_bans_user_id_idx;
+
+DROP TABLE IF EXISTS user_bans;
@@ -0,0 +1,33 @@
+DROP TABLE IF EXISTS user_bans;
+CREATE TABLE IF NOT EXISTS user_bans
+(
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT REFERENCES users      NOT NULL,
+    room_id    BIGINT REFERENCES rooms      NULL,
+    reason     VARCHAR(255)                 NULL,
+    banned_by  VARCHAR(255)                 NULL,
+    until      TIMESTAMP WITH TIME ZONE     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE     NOT NULL DEFAULT now(),
+    lifted_at  TIMESTAMP WITH TIME ZONE     NULL,
+    lifted_by  VARCHAR(255)                 NULL
+);
+
+CREATE INDEX IF NOT EXISTS user_bans_user_id_idx
+    ON user_bans USING BTREE (user_id);
+CREATE INDEX IF NOT EXISTS user_bans_until_idx
+    ON user_bans USING BTREE (until);
+
+-- re-entry blocks set on kick used to be kept in users.properties
+INSERT INTO user_bans (user_id, room_id, reason, banned_by, until)
+SELECT id,
+       nullif((properties -> 'reentry_block' ->> 'room_id')::bigint, 0),
+       'kick',
+       properties -> 'reentry_block' ->> 'by',
+       (properties -> 'reentry_block' ->> 'until')::timestamptz
+FROM users
+WHERE properties ? 'reentry_block'
+  AND (properties -> 'reentry_block' ->> 'until')::timestamptz > now();
+
+UPDATE users
+SET properties = properties - 'reentry_block'
+WHERE properties ? 'reentry_block';
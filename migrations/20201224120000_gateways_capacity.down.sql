@@ -0,0 +1,3 @@
+alter table gateways
+    drop column region,
+    drop column max_sessions;
@@ -0,0 +1,3 @@
+alter table gateways
+    add column region       VARCHAR(32) null,
+    add column max_sessions INTEGER     null;
@@ -0,0 +1,2 @@
+alter table gateways
+    drop column draining;
@@ -0,0 +1,2 @@
+alter table gateways
+    add column draining BOOLEAN NOT NULL DEFAULT FALSE;
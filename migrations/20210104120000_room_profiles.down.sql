@@ -0,0 +1,4 @@
+alter table rooms
+    drop column room_profile_id;
+
+DROP TABLE IF EXISTS room_profiles;
@@ -0,0 +1,27 @@
+-- videoroom settings of rooms, by name.
+-- rooms without a profile get the built in defaults (domain.DefaultRoomProfile).
+CREATE TABLE IF NOT EXISTS room_profiles
+(
+    id                    BIGSERIAL PRIMARY KEY,
+    name                  VARCHAR(64) UNIQUE       NOT NULL,
+    description           VARCHAR(255)             NULL,
+    publishers            INTEGER                  NOT NULL,
+    bitrate               INTEGER                  NOT NULL,
+    fir_freq              INTEGER                  NOT NULL,
+    audiocodec            VARCHAR(64)              NOT NULL,
+    videocodec            VARCHAR(64)              NOT NULL,
+    h264_profile          VARCHAR(64)              NULL,
+    opus_fec              BOOLEAN                  NOT NULL DEFAULT FALSE,
+    audiolevel_ext        BOOLEAN                  NOT NULL DEFAULT FALSE,
+    audiolevel_event      BOOLEAN                  NOT NULL DEFAULT FALSE,
+    audio_active_packets  INTEGER                  NULL,
+    audio_level_average   INTEGER                  NULL,
+    videoorient_ext       BOOLEAN                  NOT NULL DEFAULT FALSE,
+    playoutdelay_ext      BOOLEAN                  NOT NULL DEFAULT FALSE,
+    transport_wide_cc_ext BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at            TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at            TIMESTAMP WITH TIME ZONE NULL
+);
+
+alter table rooms
+    add column room_profile_id BIGINT REFERENCES room_profiles NULL;
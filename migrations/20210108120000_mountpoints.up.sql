@@ -0,0 +1,22 @@
+-- RTP mountpoints of the streaming plugin, on all streaming gateways
+CREATE TABLE IF NOT EXISTS mountpoints
+(
+    id           BIGSERIAL PRIMARY KEY,
+    name         VARCHAR(64) UNIQUE       NOT NULL,
+    gateway_uid  INTEGER UNIQUE           NOT NULL,
+    description  VARCHAR(255)             NULL,
+    audio        BOOLEAN                  NOT NULL DEFAULT FALSE,
+    audio_port   INTEGER                  NULL,
+    audio_pt     INTEGER                  NULL,
+    audio_rtpmap VARCHAR(64)              NULL,
+    video        BOOLEAN                  NOT NULL DEFAULT FALSE,
+    video_port   INTEGER                  NULL,
+    video_pt     INTEGER                  NULL,
+    video_rtpmap VARCHAR(64)              NULL,
+    video_fmtp   VARCHAR(255)             NULL,
+    data         BOOLEAN                  NOT NULL DEFAULT FALSE,
+    data_port    INTEGER                  NULL,
+    disabled     BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at   TIMESTAMP WITH TIME ZONE NULL
+);
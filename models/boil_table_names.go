@@ -6,21 +6,33 @@ package models
 var TableNames = struct {
 	Composites       string
 	CompositesRooms  string
+	DailyAttendance  string
 	DynamicConfig    string
 	Gateways         string
+	Mountpoints      string
+	Questions        string
+	RoomProfiles     string
 	RoomStatistics   string
 	Rooms            string
 	SchemaMigrations string
+	SessionEvents    string
 	Sessions         string
+	UserBans         string
 	Users            string
 }{
 	Composites:       "composites",
 	CompositesRooms:  "composites_rooms",
+	DailyAttendance:  "daily_attendance",
 	DynamicConfig:    "dynamic_config",
 	Gateways:         "gateways",
+	Mountpoints:      "mountpoints",
+	Questions:        "questions",
+	RoomProfiles:     "room_profiles",
 	RoomStatistics:   "room_statistics",
 	Rooms:            "rooms",
 	SchemaMigrations: "schema_migrations",
+	SessionEvents:    "session_events",
 	Sessions:         "sessions",
+	UserBans:         "user_bans",
 	Users:            "users",
 }
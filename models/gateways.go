@@ -36,6 +36,9 @@ type Gateway struct {
 	RemovedAt      null.Time   `boil:"removed_at" json:"removed_at,omitempty" toml:"removed_at" yaml:"removed_at,omitempty"`
 	EventsPassword string      `boil:"events_password" json:"events_password" toml:"events_password" yaml:"events_password"`
 	Type           string      `boil:"type" json:"type" toml:"type" yaml:"type"`
+	Region         null.String `boil:"region" json:"region,omitempty" toml:"region" yaml:"region,omitempty"`
+	MaxSessions    null.Int    `boil:"max_sessions" json:"max_sessions,omitempty" toml:"max_sessions" yaml:"max_sessions,omitempty"`
+	Draining       bool        `boil:"draining" json:"draining" toml:"draining" yaml:"draining"`
 
 	R *gatewayR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L gatewayL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -55,6 +58,9 @@ var GatewayColumns = struct {
 	RemovedAt      string
 	EventsPassword string
 	Type           string
+	Region         string
+	MaxSessions    string
+	Draining       string
 }{
 	ID:             "id",
 	Name:           "name",
@@ -69,6 +75,9 @@ var GatewayColumns = struct {
 	RemovedAt:      "removed_at",
 	EventsPassword: "events_password",
 	Type:           "type",
+	Region:         "region",
+	MaxSessions:    "max_sessions",
+	Draining:       "draining",
 }
 
 // Generated where
@@ -128,6 +137,29 @@ func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+type whereHelpernull_Int struct{ field string }
+
+func (w whereHelpernull_Int) EQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int) NEQ(x null.Int) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Int) LT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int) LTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int) GT(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int) GTE(x null.Int) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
 var GatewayWhere = struct {
 	ID             whereHelperint64
 	Name           whereHelperstring
@@ -142,6 +174,9 @@ var GatewayWhere = struct {
 	RemovedAt      whereHelpernull_Time
 	EventsPassword whereHelperstring
 	Type           whereHelperstring
+	Region         whereHelpernull_String
+	MaxSessions    whereHelpernull_Int
+	Draining       whereHelperbool
 }{
 	ID:             whereHelperint64{field: "\"gateways\".\"id\""},
 	Name:           whereHelperstring{field: "\"gateways\".\"name\""},
@@ -156,6 +191,9 @@ var GatewayWhere = struct {
 	RemovedAt:      whereHelpernull_Time{field: "\"gateways\".\"removed_at\""},
 	EventsPassword: whereHelperstring{field: "\"gateways\".\"events_password\""},
 	Type:           whereHelperstring{field: "\"gateways\".\"type\""},
+	Region:         whereHelpernull_String{field: "\"gateways\".\"region\""},
+	MaxSessions:    whereHelpernull_Int{field: "\"gateways\".\"max_sessions\""},
+	Draining:       whereHelperbool{field: "\"gateways\".\"draining\""},
 }
 
 // GatewayRels is where relationship names are stored.
@@ -185,9 +223,9 @@ func (*gatewayR) NewStruct() *gatewayR {
 type gatewayL struct{}
 
 var (
-	gatewayAllColumns            = []string{"id", "name", "description", "url", "admin_url", "admin_password", "disabled", "properties", "created_at", "updated_at", "removed_at", "events_password", "type"}
-	gatewayColumnsWithoutDefault = []string{"name", "description", "url", "admin_url", "admin_password", "properties", "updated_at", "removed_at", "type"}
-	gatewayColumnsWithDefault    = []string{"id", "disabled", "created_at", "events_password"}
+	gatewayAllColumns            = []string{"id", "name", "description", "url", "admin_url", "admin_password", "disabled", "properties", "created_at", "updated_at", "removed_at", "events_password", "type", "region", "max_sessions", "draining"}
+	gatewayColumnsWithoutDefault = []string{"name", "description", "url", "admin_url", "admin_password", "properties", "updated_at", "removed_at", "type", "region", "max_sessions"}
+	gatewayColumnsWithDefault    = []string{"id", "disabled", "created_at", "events_password", "draining"}
 	gatewayPrimaryKeyColumns     = []string{"id"}
 )
 
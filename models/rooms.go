@@ -34,6 +34,7 @@ type Room struct {
 	RemovedAt        null.Time   `boil:"removed_at" json:"removed_at,omitempty" toml:"removed_at" yaml:"removed_at,omitempty"`
 	Extra            null.JSON   `boil:"extra" json:"extra,omitempty" toml:"extra" yaml:"extra,omitempty"`
 	Region           null.String `boil:"region" json:"region,omitempty" toml:"region" yaml:"region,omitempty"`
+	RoomProfileID    null.Int64  `boil:"room_profile_id" json:"room_profile_id,omitempty" toml:"room_profile_id" yaml:"room_profile_id,omitempty"`
 
 	R *roomR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L roomL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -51,6 +52,7 @@ var RoomColumns = struct {
 	RemovedAt        string
 	Extra            string
 	Region           string
+	RoomProfileID    string
 }{
 	ID:               "id",
 	Name:             "name",
@@ -63,6 +65,7 @@ var RoomColumns = struct {
 	RemovedAt:        "removed_at",
 	Extra:            "extra",
 	Region:           "region",
+	RoomProfileID:    "room_profile_id",
 }
 
 // Generated where
@@ -79,6 +82,7 @@ var RoomWhere = struct {
 	RemovedAt        whereHelpernull_Time
 	Extra            whereHelpernull_JSON
 	Region           whereHelpernull_String
+	RoomProfileID    whereHelpernull_Int64
 }{
 	ID:               whereHelperint64{field: "\"rooms\".\"id\""},
 	Name:             whereHelperstring{field: "\"rooms\".\"name\""},
@@ -91,27 +95,40 @@ var RoomWhere = struct {
 	RemovedAt:        whereHelpernull_Time{field: "\"rooms\".\"removed_at\""},
 	Extra:            whereHelpernull_JSON{field: "\"rooms\".\"extra\""},
 	Region:           whereHelpernull_String{field: "\"rooms\".\"region\""},
+	RoomProfileID:    whereHelpernull_Int64{field: "\"rooms\".\"room_profile_id\""},
 }
 
 // RoomRels is where relationship names are stored.
 var RoomRels = struct {
-	DefaultGateway  string
-	RoomStatistic   string
-	CompositesRooms string
-	Sessions        string
+	DefaultGateway   string
+	RoomProfile      string
+	RoomStatistic    string
+	CompositesRooms  string
+	DailyAttendances string
+	Questions        string
+	Sessions         string
+	UserBans         string
 }{
-	DefaultGateway:  "DefaultGateway",
-	RoomStatistic:   "RoomStatistic",
-	CompositesRooms: "CompositesRooms",
-	Sessions:        "Sessions",
+	DefaultGateway:   "DefaultGateway",
+	RoomProfile:      "RoomProfile",
+	RoomStatistic:    "RoomStatistic",
+	CompositesRooms:  "CompositesRooms",
+	DailyAttendances: "DailyAttendances",
+	Questions:        "Questions",
+	Sessions:         "Sessions",
+	UserBans:         "UserBans",
 }
 
 // roomR is where relationships are stored.
 type roomR struct {
-	DefaultGateway  *Gateway
-	RoomStatistic   *RoomStatistic
-	CompositesRooms CompositesRoomSlice
-	Sessions        SessionSlice
+	DefaultGateway   *Gateway
+	RoomProfile      *RoomProfile
+	RoomStatistic    *RoomStatistic
+	CompositesRooms  CompositesRoomSlice
+	DailyAttendances DailyAttendanceSlice
+	Questions        QuestionSlice
+	Sessions         SessionSlice
+	UserBans         UserBanSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -123,8 +140,8 @@ func (*roomR) NewStruct() *roomR {
 type roomL struct{}
 
 var (
-	roomAllColumns            = []string{"id", "name", "default_gateway_id", "gateway_uid", "disabled", "properties", "created_at", "updated_at", "removed_at", "extra", "region"}
-	roomColumnsWithoutDefault = []string{"name", "default_gateway_id", "gateway_uid", "properties", "updated_at", "removed_at", "extra", "region"}
+	roomAllColumns            = []string{"id", "name", "default_gateway_id", "gateway_uid", "disabled", "properties", "created_at", "updated_at", "removed_at", "extra", "region", "room_profile_id"}
+	roomColumnsWithoutDefault = []string{"name", "default_gateway_id", "gateway_uid", "properties", "updated_at", "removed_at", "extra", "region", "room_profile_id"}
 	roomColumnsWithDefault    = []string{"id", "disabled", "created_at"}
 	roomPrimaryKeyColumns     = []string{"id"}
 )
@@ -234,6 +251,20 @@ func (o *Room) DefaultGateway(mods ...qm.QueryMod) gatewayQuery {
 	return query
 }
 
+// RoomProfile pointed to by the foreign key.
+func (o *Room) RoomProfile(mods ...qm.QueryMod) roomProfileQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.RoomProfileID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := RoomProfiles(queryMods...)
+	queries.SetFrom(query.Query, "\"room_profiles\"")
+
+	return query
+}
+
 // RoomStatistic pointed to by the foreign key.
 func (o *Room) RoomStatistic(mods ...qm.QueryMod) roomStatisticQuery {
 	queryMods := []qm.QueryMod{
@@ -269,6 +300,48 @@ func (o *Room) CompositesRooms(mods ...qm.QueryMod) compositesRoomQuery {
 	return query
 }
 
+// DailyAttendances retrieves all the daily_attendance's DailyAttendances with an executor.
+func (o *Room) DailyAttendances(mods ...qm.QueryMod) dailyAttendanceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"daily_attendance\".\"room_id\"=?", o.ID),
+	)
+
+	query := DailyAttendances(queryMods...)
+	queries.SetFrom(query.Query, "\"daily_attendance\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"daily_attendance\".*"})
+	}
+
+	return query
+}
+
+// Questions retrieves all the question's Questions with an executor.
+func (o *Room) Questions(mods ...qm.QueryMod) questionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"questions\".\"room_id\"=?", o.ID),
+	)
+
+	query := Questions(queryMods...)
+	queries.SetFrom(query.Query, "\"questions\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"questions\".*"})
+	}
+
+	return query
+}
+
 // Sessions retrieves all the session's Sessions with an executor.
 func (o *Room) Sessions(mods ...qm.QueryMod) sessionQuery {
 	var queryMods []qm.QueryMod
@@ -290,6 +363,27 @@ func (o *Room) Sessions(mods ...qm.QueryMod) sessionQuery {
 	return query
 }
 
+// UserBans retrieves all the user_ban's UserBans with an executor.
+func (o *Room) UserBans(mods ...qm.QueryMod) userBanQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"user_bans\".\"room_id\"=?", o.ID),
+	)
+
+	query := UserBans(queryMods...)
+	queries.SetFrom(query.Query, "\"user_bans\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"user_bans\".*"})
+	}
+
+	return query
+}
+
 // LoadDefaultGateway allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (roomL) LoadDefaultGateway(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
@@ -383,6 +477,103 @@ func (roomL) LoadDefaultGateway(e boil.Executor, singular bool, maybeRoom interf
 	return nil
 }
 
+// LoadRoomProfile allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (roomL) LoadRoomProfile(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
+	var slice []*Room
+	var object *Room
+
+	if singular {
+		object = maybeRoom.(*Room)
+	} else {
+		slice = *maybeRoom.(*[]*Room)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &roomR{}
+		}
+		if !queries.IsNil(object.RoomProfileID) {
+			args = append(args, object.RoomProfileID)
+		}
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &roomR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.RoomProfileID) {
+					continue Outer
+				}
+			}
+
+			if !queries.IsNil(obj.RoomProfileID) {
+				args = append(args, obj.RoomProfileID)
+			}
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`room_profiles`), qm.WhereIn(`room_profiles.id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load RoomProfile")
+	}
+
+	var resultSlice []*RoomProfile
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice RoomProfile")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for room_profiles")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for room_profiles")
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.RoomProfile = foreign
+		if foreign.R == nil {
+			foreign.R = &roomProfileR{}
+		}
+		foreign.R.Rooms = append(foreign.R.Rooms, object)
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if queries.Equal(local.RoomProfileID, foreign.ID) {
+				local.R.RoomProfile = foreign
+				if foreign.R == nil {
+					foreign.R = &roomProfileR{}
+				}
+				foreign.R.Rooms = append(foreign.R.Rooms, local)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadRoomStatistic allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
 func (roomL) LoadRoomStatistic(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
@@ -561,9 +752,9 @@ func (roomL) LoadCompositesRooms(e boil.Executor, singular bool, maybeRoom inter
 	return nil
 }
 
-// LoadSessions allows an eager lookup of values, cached into the
+// LoadDailyAttendances allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
-func (roomL) LoadSessions(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
+func (roomL) LoadDailyAttendances(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
 	var slice []*Room
 	var object *Room
 
@@ -587,7 +778,7 @@ func (roomL) LoadSessions(e boil.Executor, singular bool, maybeRoom interface{},
 			}
 
 			for _, a := range args {
-				if queries.Equal(a, obj.ID) {
+				if a == obj.ID {
 					continue Outer
 				}
 			}
@@ -600,33 +791,33 @@ func (roomL) LoadSessions(e boil.Executor, singular bool, maybeRoom interface{},
 		return nil
 	}
 
-	query := NewQuery(qm.From(`sessions`), qm.WhereIn(`sessions.room_id in ?`, args...))
+	query := NewQuery(qm.From(`daily_attendance`), qm.WhereIn(`daily_attendance.room_id in ?`, args...))
 	if mods != nil {
 		mods.Apply(query)
 	}
 
 	results, err := query.Query(e)
 	if err != nil {
-		return errors.Wrap(err, "failed to eager load sessions")
+		return errors.Wrap(err, "failed to eager load daily_attendance")
 	}
 
-	var resultSlice []*Session
+	var resultSlice []*DailyAttendance
 	if err = queries.Bind(results, &resultSlice); err != nil {
-		return errors.Wrap(err, "failed to bind eager loaded slice sessions")
+		return errors.Wrap(err, "failed to bind eager loaded slice daily_attendance")
 	}
 
 	if err = results.Close(); err != nil {
-		return errors.Wrap(err, "failed to close results in eager load on sessions")
+		return errors.Wrap(err, "failed to close results in eager load on daily_attendance")
 	}
 	if err = results.Err(); err != nil {
-		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for sessions")
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for daily_attendance")
 	}
 
 	if singular {
-		object.R.Sessions = resultSlice
+		object.R.DailyAttendances = resultSlice
 		for _, foreign := range resultSlice {
 			if foreign.R == nil {
-				foreign.R = &sessionR{}
+				foreign.R = &dailyAttendanceR{}
 			}
 			foreign.R.Room = object
 		}
@@ -635,10 +826,10 @@ func (roomL) LoadSessions(e boil.Executor, singular bool, maybeRoom interface{},
 
 	for _, foreign := range resultSlice {
 		for _, local := range slice {
-			if queries.Equal(local.ID, foreign.RoomID) {
-				local.R.Sessions = append(local.R.Sessions, foreign)
+			if local.ID == foreign.RoomID {
+				local.R.DailyAttendances = append(local.R.DailyAttendances, foreign)
 				if foreign.R == nil {
-					foreign.R = &sessionR{}
+					foreign.R = &dailyAttendanceR{}
 				}
 				foreign.R.Room = local
 				break
@@ -649,66 +840,409 @@ func (roomL) LoadSessions(e boil.Executor, singular bool, maybeRoom interface{},
 	return nil
 }
 
-// SetDefaultGateway of the room to the related item.
-// Sets o.R.DefaultGateway to related.
-// Adds o to related.R.DefaultGatewayRooms.
-func (o *Room) SetDefaultGateway(exec boil.Executor, insert bool, related *Gateway) error {
-	var err error
-	if insert {
-		if err = related.Insert(exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
+// LoadQuestions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (roomL) LoadQuestions(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
+	var slice []*Room
+	var object *Room
+
+	if singular {
+		object = maybeRoom.(*Room)
+	} else {
+		slice = *maybeRoom.(*[]*Room)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &roomR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &roomR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
 		}
 	}
 
-	updateQuery := fmt.Sprintf(
-		"UPDATE \"rooms\" SET %s WHERE %s",
-		strmangle.SetParamNames("\"", "\"", 1, []string{"default_gateway_id"}),
-		strmangle.WhereClause("\"", "\"", 2, roomPrimaryKeyColumns),
-	)
-	values := []interface{}{related.ID, o.ID}
+	if len(args) == 0 {
+		return nil
+	}
 
-	if boil.DebugMode {
-		fmt.Fprintln(boil.DebugWriter, updateQuery)
-		fmt.Fprintln(boil.DebugWriter, values)
+	query := NewQuery(qm.From(`questions`), qm.WhereIn(`questions.room_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
 	}
-	if _, err = exec.Exec(updateQuery, values...); err != nil {
-		return errors.Wrap(err, "failed to update local table")
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load questions")
 	}
 
-	o.DefaultGatewayID = related.ID
-	if o.R == nil {
-		o.R = &roomR{
-			DefaultGateway: related,
+	var resultSlice []*Question
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice questions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on questions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for questions")
+	}
+
+	if singular {
+		object.R.Questions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &questionR{}
+			}
+			foreign.R.Room = object
 		}
-	} else {
-		o.R.DefaultGateway = related
+		return nil
 	}
 
-	if related.R == nil {
-		related.R = &gatewayR{
-			DefaultGatewayRooms: RoomSlice{o},
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.RoomID {
+				local.R.Questions = append(local.R.Questions, foreign)
+				if foreign.R == nil {
+					foreign.R = &questionR{}
+				}
+				foreign.R.Room = local
+				break
+			}
 		}
-	} else {
-		related.R.DefaultGatewayRooms = append(related.R.DefaultGatewayRooms, o)
 	}
 
 	return nil
 }
 
-// SetRoomStatistic of the room to the related item.
-// Sets o.R.RoomStatistic to related.
-// Adds o to related.R.Room.
-func (o *Room) SetRoomStatistic(exec boil.Executor, insert bool, related *RoomStatistic) error {
-	var err error
-
-	if insert {
-		related.RoomID = o.ID
+// LoadSessions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (roomL) LoadSessions(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
+	var slice []*Room
+	var object *Room
 
-		if err = related.Insert(exec, boil.Infer()); err != nil {
-			return errors.Wrap(err, "failed to insert into foreign table")
-		}
+	if singular {
+		object = maybeRoom.(*Room)
 	} else {
-		updateQuery := fmt.Sprintf(
+		slice = *maybeRoom.(*[]*Room)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &roomR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &roomR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`sessions`), qm.WhereIn(`sessions.room_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load sessions")
+	}
+
+	var resultSlice []*Session
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice sessions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on sessions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for sessions")
+	}
+
+	if singular {
+		object.R.Sessions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &sessionR{}
+			}
+			foreign.R.Room = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.RoomID) {
+				local.R.Sessions = append(local.R.Sessions, foreign)
+				if foreign.R == nil {
+					foreign.R = &sessionR{}
+				}
+				foreign.R.Room = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadUserBans allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (roomL) LoadUserBans(e boil.Executor, singular bool, maybeRoom interface{}, mods queries.Applicator) error {
+	var slice []*Room
+	var object *Room
+
+	if singular {
+		object = maybeRoom.(*Room)
+	} else {
+		slice = *maybeRoom.(*[]*Room)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &roomR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &roomR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`user_bans`), qm.WhereIn(`user_bans.room_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_bans")
+	}
+
+	var resultSlice []*UserBan
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_bans")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_bans")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_bans")
+	}
+
+	if singular {
+		object.R.UserBans = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userBanR{}
+			}
+			foreign.R.Room = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.RoomID) {
+				local.R.UserBans = append(local.R.UserBans, foreign)
+				if foreign.R == nil {
+					foreign.R = &userBanR{}
+				}
+				foreign.R.Room = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetDefaultGateway of the room to the related item.
+// Sets o.R.DefaultGateway to related.
+// Adds o to related.R.DefaultGatewayRooms.
+func (o *Room) SetDefaultGateway(exec boil.Executor, insert bool, related *Gateway) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"rooms\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"default_gateway_id"}),
+		strmangle.WhereClause("\"", "\"", 2, roomPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.DefaultGatewayID = related.ID
+	if o.R == nil {
+		o.R = &roomR{
+			DefaultGateway: related,
+		}
+	} else {
+		o.R.DefaultGateway = related
+	}
+
+	if related.R == nil {
+		related.R = &gatewayR{
+			DefaultGatewayRooms: RoomSlice{o},
+		}
+	} else {
+		related.R.DefaultGatewayRooms = append(related.R.DefaultGatewayRooms, o)
+	}
+
+	return nil
+}
+
+// SetRoomProfile of the room to the related item.
+// Sets o.R.RoomProfile to related.
+// Adds o to related.R.Rooms.
+func (o *Room) SetRoomProfile(exec boil.Executor, insert bool, related *RoomProfile) error {
+	var err error
+	if insert {
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"rooms\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, []string{"room_profile_id"}),
+		strmangle.WhereClause("\"", "\"", 2, roomPrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	if _, err = exec.Exec(updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	queries.Assign(&o.RoomProfileID, related.ID)
+	if o.R == nil {
+		o.R = &roomR{
+			RoomProfile: related,
+		}
+	} else {
+		o.R.RoomProfile = related
+	}
+
+	if related.R == nil {
+		related.R = &roomProfileR{
+			Rooms: RoomSlice{o},
+		}
+	} else {
+		related.R.Rooms = append(related.R.Rooms, o)
+	}
+
+	return nil
+}
+
+// RemoveRoomProfile relationship.
+// Sets o.R.RoomProfile to nil.
+// Removes o from all passed in related items' relationships struct (Optional).
+func (o *Room) RemoveRoomProfile(exec boil.Executor, related *RoomProfile) error {
+	var err error
+
+	queries.SetScanner(&o.RoomProfileID, nil)
+	if _, err = o.Update(exec, boil.Whitelist("room_profile_id")); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	if o.R != nil {
+		o.R.RoomProfile = nil
+	}
+	if related == nil || related.R == nil {
+		return nil
+	}
+
+	for i, ri := range related.R.Rooms {
+		if queries.Equal(o.RoomProfileID, ri.RoomProfileID) {
+			continue
+		}
+
+		ln := len(related.R.Rooms)
+		if ln > 1 && i < ln-1 {
+			related.R.Rooms[i] = related.R.Rooms[ln-1]
+		}
+		related.R.Rooms = related.R.Rooms[:ln-1]
+		break
+	}
+	return nil
+}
+
+// SetRoomStatistic of the room to the related item.
+// Sets o.R.RoomStatistic to related.
+// Adds o to related.R.Room.
+func (o *Room) SetRoomStatistic(exec boil.Executor, insert bool, related *RoomStatistic) error {
+	var err error
+
+	if insert {
+		related.RoomID = o.ID
+
+		if err = related.Insert(exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
 			"UPDATE \"room_statistics\" SET %s WHERE %s",
 			strmangle.SetParamNames("\"", "\"", 1, []string{"room_id"}),
 			strmangle.WhereClause("\"", "\"", 2, roomStatisticPrimaryKeyColumns),
@@ -797,6 +1331,110 @@ func (o *Room) AddCompositesRooms(exec boil.Executor, insert bool, related ...*C
 	return nil
 }
 
+// AddDailyAttendances adds the given related objects to the existing relationships
+// of the room, optionally inserting them as new records.
+// Appends related to o.R.DailyAttendances.
+// Sets related.R.Room appropriately.
+func (o *Room) AddDailyAttendances(exec boil.Executor, insert bool, related ...*DailyAttendance) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.RoomID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"daily_attendance\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"room_id"}),
+				strmangle.WhereClause("\"", "\"", 2, dailyAttendancePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.RoomID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &roomR{
+			DailyAttendances: related,
+		}
+	} else {
+		o.R.DailyAttendances = append(o.R.DailyAttendances, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &dailyAttendanceR{
+				Room: o,
+			}
+		} else {
+			rel.R.Room = o
+		}
+	}
+	return nil
+}
+
+// AddQuestions adds the given related objects to the existing relationships
+// of the room, optionally inserting them as new records.
+// Appends related to o.R.Questions.
+// Sets related.R.Room appropriately.
+func (o *Room) AddQuestions(exec boil.Executor, insert bool, related ...*Question) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.RoomID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"questions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"room_id"}),
+				strmangle.WhereClause("\"", "\"", 2, questionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.RoomID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &roomR{
+			Questions: related,
+		}
+	} else {
+		o.R.Questions = append(o.R.Questions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &questionR{
+				Room: o,
+			}
+		} else {
+			rel.R.Room = o
+		}
+	}
+	return nil
+}
+
 // AddSessions adds the given related objects to the existing relationships
 // of the room, optionally inserting them as new records.
 // Appends related to o.R.Sessions.
@@ -918,6 +1556,127 @@ func (o *Room) RemoveSessions(exec boil.Executor, related ...*Session) error {
 	return nil
 }
 
+// AddUserBans adds the given related objects to the existing relationships
+// of the room, optionally inserting them as new records.
+// Appends related to o.R.UserBans.
+// Sets related.R.Room appropriately.
+func (o *Room) AddUserBans(exec boil.Executor, insert bool, related ...*UserBan) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.RoomID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"user_bans\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"room_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userBanPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.RoomID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &roomR{
+			UserBans: related,
+		}
+	} else {
+		o.R.UserBans = append(o.R.UserBans, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userBanR{
+				Room: o,
+			}
+		} else {
+			rel.R.Room = o
+		}
+	}
+	return nil
+}
+
+// SetUserBans removes all previously related items of the
+// room replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Room's UserBans accordingly.
+// Replaces o.R.UserBans with related.
+// Sets related.R.Room's UserBans accordingly.
+func (o *Room) SetUserBans(exec boil.Executor, insert bool, related ...*UserBan) error {
+	query := "update \"user_bans\" set \"room_id\" = null where \"room_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.UserBans {
+			queries.SetScanner(&rel.RoomID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Room = nil
+		}
+
+		o.R.UserBans = nil
+	}
+	return o.AddUserBans(exec, insert, related...)
+}
+
+// RemoveUserBans relationships from objects passed in.
+// Removes related items from R.UserBans (uses pointer comparison, removal does not keep order)
+// Sets related.R.Room.
+func (o *Room) RemoveUserBans(exec boil.Executor, related ...*UserBan) error {
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.RoomID, nil)
+		if rel.R != nil {
+			rel.R.Room = nil
+		}
+		if _, err = rel.Update(exec, boil.Whitelist("room_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.UserBans {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.UserBans)
+			if ln > 1 && i < ln-1 {
+				o.R.UserBans[i] = o.R.UserBans[ln-1]
+			}
+			o.R.UserBans = o.R.UserBans[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
 // Rooms retrieves all the records using an executor.
 func Rooms(mods ...qm.QueryMod) roomQuery {
 	mods = append(mods, qm.From("\"rooms\""))
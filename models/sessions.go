@@ -94,29 +94,6 @@ var SessionColumns = struct {
 
 // Generated where
 
-type whereHelpernull_Int64 struct{ field string }
-
-func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, false, x)
-}
-func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
-	return qmhelper.WhereNullEQ(w.field, true, x)
-}
-func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
-func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LT, x)
-}
-func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.LTE, x)
-}
-func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GT, x)
-}
-func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.GTE, x)
-}
-
 var SessionWhere = struct {
 	ID                    whereHelperint64
 	UserID                whereHelperint64
@@ -163,20 +140,26 @@ var SessionWhere = struct {
 
 // SessionRels is where relationship names are stored.
 var SessionRels = struct {
-	Gateway string
-	Room    string
-	User    string
+	Gateway       string
+	Room          string
+	User          string
+	Questions     string
+	SessionEvents string
 }{
-	Gateway: "Gateway",
-	Room:    "Room",
-	User:    "User",
+	Gateway:       "Gateway",
+	Room:          "Room",
+	User:          "User",
+	Questions:     "Questions",
+	SessionEvents: "SessionEvents",
 }
 
 // sessionR is where relationships are stored.
 type sessionR struct {
-	Gateway *Gateway
-	Room    *Room
-	User    *User
+	Gateway       *Gateway
+	Room          *Room
+	User          *User
+	Questions     QuestionSlice
+	SessionEvents SessionEventSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -327,6 +310,48 @@ func (o *Session) User(mods ...qm.QueryMod) userQuery {
 	return query
 }
 
+// Questions retrieves all the question's Questions with an executor.
+func (o *Session) Questions(mods ...qm.QueryMod) questionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"questions\".\"session_id\"=?", o.ID),
+	)
+
+	query := Questions(queryMods...)
+	queries.SetFrom(query.Query, "\"questions\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"questions\".*"})
+	}
+
+	return query
+}
+
+// SessionEvents retrieves all the session_event's SessionEvents with an executor.
+func (o *Session) SessionEvents(mods ...qm.QueryMod) sessionEventQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"session_events\".\"session_id\"=?", o.ID),
+	)
+
+	query := SessionEvents(queryMods...)
+	queries.SetFrom(query.Query, "\"session_events\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"session_events\".*"})
+	}
+
+	return query
+}
+
 // LoadGateway allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for an N-1 relationship.
 func (sessionL) LoadGateway(e boil.Executor, singular bool, maybeSession interface{}, mods queries.Applicator) error {
@@ -614,6 +639,182 @@ func (sessionL) LoadUser(e boil.Executor, singular bool, maybeSession interface{
 	return nil
 }
 
+// LoadQuestions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (sessionL) LoadQuestions(e boil.Executor, singular bool, maybeSession interface{}, mods queries.Applicator) error {
+	var slice []*Session
+	var object *Session
+
+	if singular {
+		object = maybeSession.(*Session)
+	} else {
+		slice = *maybeSession.(*[]*Session)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &sessionR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &sessionR{}
+			}
+
+			for _, a := range args {
+				if queries.Equal(a, obj.ID) {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`questions`), qm.WhereIn(`questions.session_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load questions")
+	}
+
+	var resultSlice []*Question
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice questions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on questions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for questions")
+	}
+
+	if singular {
+		object.R.Questions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &questionR{}
+			}
+			foreign.R.Session = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if queries.Equal(local.ID, foreign.SessionID) {
+				local.R.Questions = append(local.R.Questions, foreign)
+				if foreign.R == nil {
+					foreign.R = &questionR{}
+				}
+				foreign.R.Session = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSessionEvents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (sessionL) LoadSessionEvents(e boil.Executor, singular bool, maybeSession interface{}, mods queries.Applicator) error {
+	var slice []*Session
+	var object *Session
+
+	if singular {
+		object = maybeSession.(*Session)
+	} else {
+		slice = *maybeSession.(*[]*Session)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &sessionR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &sessionR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`session_events`), qm.WhereIn(`session_events.session_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load session_events")
+	}
+
+	var resultSlice []*SessionEvent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice session_events")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on session_events")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for session_events")
+	}
+
+	if singular {
+		object.R.SessionEvents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &sessionEventR{}
+			}
+			foreign.R.Session = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.SessionID {
+				local.R.SessionEvents = append(local.R.SessionEvents, foreign)
+				if foreign.R == nil {
+					foreign.R = &sessionEventR{}
+				}
+				foreign.R.Session = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // SetGateway of the session to the related item.
 // Sets o.R.Gateway to related.
 // Adds o to related.R.Sessions.
@@ -818,6 +1019,179 @@ func (o *Session) SetUser(exec boil.Executor, insert bool, related *User) error
 	return nil
 }
 
+// AddQuestions adds the given related objects to the existing relationships
+// of the session, optionally inserting them as new records.
+// Appends related to o.R.Questions.
+// Sets related.R.Session appropriately.
+func (o *Session) AddQuestions(exec boil.Executor, insert bool, related ...*Question) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			queries.Assign(&rel.SessionID, o.ID)
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"questions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"session_id"}),
+				strmangle.WhereClause("\"", "\"", 2, questionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			queries.Assign(&rel.SessionID, o.ID)
+		}
+	}
+
+	if o.R == nil {
+		o.R = &sessionR{
+			Questions: related,
+		}
+	} else {
+		o.R.Questions = append(o.R.Questions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &questionR{
+				Session: o,
+			}
+		} else {
+			rel.R.Session = o
+		}
+	}
+	return nil
+}
+
+// SetQuestions removes all previously related items of the
+// session replacing them completely with the passed
+// in related items, optionally inserting them as new records.
+// Sets o.R.Session's Questions accordingly.
+// Replaces o.R.Questions with related.
+// Sets related.R.Session's Questions accordingly.
+func (o *Session) SetQuestions(exec boil.Executor, insert bool, related ...*Question) error {
+	query := "update \"questions\" set \"session_id\" = null where \"session_id\" = $1"
+	values := []interface{}{o.ID}
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+	_, err := exec.Exec(query, values...)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove relationships before set")
+	}
+
+	if o.R != nil {
+		for _, rel := range o.R.Questions {
+			queries.SetScanner(&rel.SessionID, nil)
+			if rel.R == nil {
+				continue
+			}
+
+			rel.R.Session = nil
+		}
+
+		o.R.Questions = nil
+	}
+	return o.AddQuestions(exec, insert, related...)
+}
+
+// RemoveQuestions relationships from objects passed in.
+// Removes related items from R.Questions (uses pointer comparison, removal does not keep order)
+// Sets related.R.Session.
+func (o *Session) RemoveQuestions(exec boil.Executor, related ...*Question) error {
+	var err error
+	for _, rel := range related {
+		queries.SetScanner(&rel.SessionID, nil)
+		if rel.R != nil {
+			rel.R.Session = nil
+		}
+		if _, err = rel.Update(exec, boil.Whitelist("session_id")); err != nil {
+			return err
+		}
+	}
+	if o.R == nil {
+		return nil
+	}
+
+	for _, rel := range related {
+		for i, ri := range o.R.Questions {
+			if rel != ri {
+				continue
+			}
+
+			ln := len(o.R.Questions)
+			if ln > 1 && i < ln-1 {
+				o.R.Questions[i] = o.R.Questions[ln-1]
+			}
+			o.R.Questions = o.R.Questions[:ln-1]
+			break
+		}
+	}
+
+	return nil
+}
+
+// AddSessionEvents adds the given related objects to the existing relationships
+// of the session, optionally inserting them as new records.
+// Appends related to o.R.SessionEvents.
+// Sets related.R.Session appropriately.
+func (o *Session) AddSessionEvents(exec boil.Executor, insert bool, related ...*SessionEvent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.SessionID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"session_events\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"session_id"}),
+				strmangle.WhereClause("\"", "\"", 2, sessionEventPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.SessionID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &sessionR{
+			SessionEvents: related,
+		}
+	} else {
+		o.R.SessionEvents = append(o.R.SessionEvents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &sessionEventR{
+				Session: o,
+			}
+		} else {
+			rel.R.Session = o
+		}
+	}
+	return nil
+}
+
 // Sessions retrieves all the records using an executor.
 func Sessions(mods ...qm.QueryMod) sessionQuery {
 	mods = append(mods, qm.From("\"sessions\""))
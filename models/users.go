@@ -34,6 +34,7 @@ type User struct {
 	CreatedAt  time.Time   `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
 	UpdatedAt  null.Time   `boil:"updated_at" json:"updated_at,omitempty" toml:"updated_at" yaml:"updated_at,omitempty"`
 	RemovedAt  null.Time   `boil:"removed_at" json:"removed_at,omitempty" toml:"removed_at" yaml:"removed_at,omitempty"`
+	LastSeenAt null.Time   `boil:"last_seen_at" json:"last_seen_at,omitempty" toml:"last_seen_at" yaml:"last_seen_at,omitempty"`
 
 	R *userR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L userL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -51,6 +52,7 @@ var UserColumns = struct {
 	CreatedAt  string
 	UpdatedAt  string
 	RemovedAt  string
+	LastSeenAt string
 }{
 	ID:         "id",
 	AccountsID: "accounts_id",
@@ -63,6 +65,7 @@ var UserColumns = struct {
 	CreatedAt:  "created_at",
 	UpdatedAt:  "updated_at",
 	RemovedAt:  "removed_at",
+	LastSeenAt: "last_seen_at",
 }
 
 // Generated where
@@ -79,6 +82,7 @@ var UserWhere = struct {
 	CreatedAt  whereHelpertime_Time
 	UpdatedAt  whereHelpernull_Time
 	RemovedAt  whereHelpernull_Time
+	LastSeenAt whereHelpernull_Time
 }{
 	ID:         whereHelperint64{field: "\"users\".\"id\""},
 	AccountsID: whereHelperstring{field: "\"users\".\"accounts_id\""},
@@ -91,18 +95,31 @@ var UserWhere = struct {
 	CreatedAt:  whereHelpertime_Time{field: "\"users\".\"created_at\""},
 	UpdatedAt:  whereHelpernull_Time{field: "\"users\".\"updated_at\""},
 	RemovedAt:  whereHelpernull_Time{field: "\"users\".\"removed_at\""},
+	LastSeenAt: whereHelpernull_Time{field: "\"users\".\"last_seen_at\""},
 }
 
 // UserRels is where relationship names are stored.
 var UserRels = struct {
-	Sessions string
+	DailyAttendances string
+	Questions        string
+	SessionEvents    string
+	Sessions         string
+	UserBans         string
 }{
-	Sessions: "Sessions",
+	DailyAttendances: "DailyAttendances",
+	Questions:        "Questions",
+	SessionEvents:    "SessionEvents",
+	Sessions:         "Sessions",
+	UserBans:         "UserBans",
 }
 
 // userR is where relationships are stored.
 type userR struct {
-	Sessions SessionSlice
+	DailyAttendances DailyAttendanceSlice
+	Questions        QuestionSlice
+	SessionEvents    SessionEventSlice
+	Sessions         SessionSlice
+	UserBans         UserBanSlice
 }
 
 // NewStruct creates a new relationship struct
@@ -114,8 +131,8 @@ func (*userR) NewStruct() *userR {
 type userL struct{}
 
 var (
-	userAllColumns            = []string{"id", "accounts_id", "email", "first_name", "last_name", "username", "disabled", "properties", "created_at", "updated_at", "removed_at"}
-	userColumnsWithoutDefault = []string{"accounts_id", "email", "first_name", "last_name", "username", "properties", "updated_at", "removed_at"}
+	userAllColumns            = []string{"id", "accounts_id", "email", "first_name", "last_name", "username", "disabled", "properties", "created_at", "updated_at", "removed_at", "last_seen_at"}
+	userColumnsWithoutDefault = []string{"accounts_id", "email", "first_name", "last_name", "username", "properties", "updated_at", "removed_at", "last_seen_at"}
 	userColumnsWithDefault    = []string{"id", "disabled", "created_at"}
 	userPrimaryKeyColumns     = []string{"id"}
 )
@@ -211,6 +228,69 @@ func (q userQuery) Exists(exec boil.Executor) (bool, error) {
 	return count > 0, nil
 }
 
+// DailyAttendances retrieves all the daily_attendance's DailyAttendances with an executor.
+func (o *User) DailyAttendances(mods ...qm.QueryMod) dailyAttendanceQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"daily_attendance\".\"user_id\"=?", o.ID),
+	)
+
+	query := DailyAttendances(queryMods...)
+	queries.SetFrom(query.Query, "\"daily_attendance\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"daily_attendance\".*"})
+	}
+
+	return query
+}
+
+// Questions retrieves all the question's Questions with an executor.
+func (o *User) Questions(mods ...qm.QueryMod) questionQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"questions\".\"user_id\"=?", o.ID),
+	)
+
+	query := Questions(queryMods...)
+	queries.SetFrom(query.Query, "\"questions\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"questions\".*"})
+	}
+
+	return query
+}
+
+// SessionEvents retrieves all the session_event's SessionEvents with an executor.
+func (o *User) SessionEvents(mods ...qm.QueryMod) sessionEventQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"session_events\".\"user_id\"=?", o.ID),
+	)
+
+	query := SessionEvents(queryMods...)
+	queries.SetFrom(query.Query, "\"session_events\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"session_events\".*"})
+	}
+
+	return query
+}
+
 // Sessions retrieves all the session's Sessions with an executor.
 func (o *User) Sessions(mods ...qm.QueryMod) sessionQuery {
 	var queryMods []qm.QueryMod
@@ -229,7 +309,292 @@ func (o *User) Sessions(mods ...qm.QueryMod) sessionQuery {
 		queries.SetSelect(query.Query, []string{"\"sessions\".*"})
 	}
 
-	return query
+	return query
+}
+
+// UserBans retrieves all the user_ban's UserBans with an executor.
+func (o *User) UserBans(mods ...qm.QueryMod) userBanQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"user_bans\".\"user_id\"=?", o.ID),
+	)
+
+	query := UserBans(queryMods...)
+	queries.SetFrom(query.Query, "\"user_bans\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"user_bans\".*"})
+	}
+
+	return query
+}
+
+// LoadDailyAttendances allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadDailyAttendances(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`daily_attendance`), qm.WhereIn(`daily_attendance.user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load daily_attendance")
+	}
+
+	var resultSlice []*DailyAttendance
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice daily_attendance")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on daily_attendance")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for daily_attendance")
+	}
+
+	if singular {
+		object.R.DailyAttendances = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &dailyAttendanceR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.DailyAttendances = append(local.R.DailyAttendances, foreign)
+				if foreign.R == nil {
+					foreign.R = &dailyAttendanceR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadQuestions allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadQuestions(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`questions`), qm.WhereIn(`questions.user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load questions")
+	}
+
+	var resultSlice []*Question
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice questions")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on questions")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for questions")
+	}
+
+	if singular {
+		object.R.Questions = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &questionR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.Questions = append(local.R.Questions, foreign)
+				if foreign.R == nil {
+					foreign.R = &questionR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// LoadSessionEvents allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadSessionEvents(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`session_events`), qm.WhereIn(`session_events.user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load session_events")
+	}
+
+	var resultSlice []*SessionEvent
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice session_events")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on session_events")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for session_events")
+	}
+
+	if singular {
+		object.R.SessionEvents = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &sessionEventR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.SessionEvents = append(local.R.SessionEvents, foreign)
+				if foreign.R == nil {
+					foreign.R = &sessionEventR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
 }
 
 // LoadSessions allows an eager lookup of values, cached into the
@@ -320,6 +685,250 @@ func (userL) LoadSessions(e boil.Executor, singular bool, maybeUser interface{},
 	return nil
 }
 
+// LoadUserBans allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (userL) LoadUserBans(e boil.Executor, singular bool, maybeUser interface{}, mods queries.Applicator) error {
+	var slice []*User
+	var object *User
+
+	if singular {
+		object = maybeUser.(*User)
+	} else {
+		slice = *maybeUser.(*[]*User)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &userR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &userR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`user_bans`), qm.WhereIn(`user_bans.user_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.Query(e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load user_bans")
+	}
+
+	var resultSlice []*UserBan
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice user_bans")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on user_bans")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for user_bans")
+	}
+
+	if singular {
+		object.R.UserBans = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &userBanR{}
+			}
+			foreign.R.User = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.UserID {
+				local.R.UserBans = append(local.R.UserBans, foreign)
+				if foreign.R == nil {
+					foreign.R = &userBanR{}
+				}
+				foreign.R.User = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// AddDailyAttendances adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.DailyAttendances.
+// Sets related.R.User appropriately.
+func (o *User) AddDailyAttendances(exec boil.Executor, insert bool, related ...*DailyAttendance) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"daily_attendance\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, dailyAttendancePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			DailyAttendances: related,
+		}
+	} else {
+		o.R.DailyAttendances = append(o.R.DailyAttendances, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &dailyAttendanceR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddQuestions adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.Questions.
+// Sets related.R.User appropriately.
+func (o *User) AddQuestions(exec boil.Executor, insert bool, related ...*Question) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"questions\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, questionPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			Questions: related,
+		}
+	} else {
+		o.R.Questions = append(o.R.Questions, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &questionR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
+// AddSessionEvents adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.SessionEvents.
+// Sets related.R.User appropriately.
+func (o *User) AddSessionEvents(exec boil.Executor, insert bool, related ...*SessionEvent) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"session_events\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, sessionEventPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			SessionEvents: related,
+		}
+	} else {
+		o.R.SessionEvents = append(o.R.SessionEvents, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &sessionEventR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // AddSessions adds the given related objects to the existing relationships
 // of the user, optionally inserting them as new records.
 // Appends related to o.R.Sessions.
@@ -372,6 +981,58 @@ func (o *User) AddSessions(exec boil.Executor, insert bool, related ...*Session)
 	return nil
 }
 
+// AddUserBans adds the given related objects to the existing relationships
+// of the user, optionally inserting them as new records.
+// Appends related to o.R.UserBans.
+// Sets related.R.User appropriately.
+func (o *User) AddUserBans(exec boil.Executor, insert bool, related ...*UserBan) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.UserID = o.ID
+			if err = rel.Insert(exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"user_bans\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"user_id"}),
+				strmangle.WhereClause("\"", "\"", 2, userBanPrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+			if _, err = exec.Exec(updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.UserID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &userR{
+			UserBans: related,
+		}
+	} else {
+		o.R.UserBans = append(o.R.UserBans, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &userBanR{
+				User: o,
+			}
+		} else {
+			rel.R.User = o
+		}
+	}
+	return nil
+}
+
 // Users retrieves all the records using an executor.
 func Users(mods ...qm.QueryMod) userQuery {
 	mods = append(mods, qm.From("\"users\""))